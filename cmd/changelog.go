@@ -0,0 +1,20 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// changelogCmd represents the changelog command
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Short: "Manage changelog",
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.Help())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(changelogCmd)
+}
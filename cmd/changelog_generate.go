@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"os"
+
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var changelogGenerateCmd = &cobra.Command{
+	Use:   "generate",
+	Short: "Rebuild the whole changelog from semver tags",
+	Run:   setupChangelogGenerateCmdFunc(changelogGenerate),
+}
+
+func init() {
+	changelogGenerateCmd.Flags().String("output", github.ChangelogFilename, "file the changelog is written to")
+	changelogCmd.AddCommand(changelogGenerateCmd)
+}
+
+func setupChangelogGenerateCmdFunc(f func(messageHandler, changelogService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getChangelogService(), cmd, args)
+	}
+}
+
+func changelogGenerate(msgHandler messageHandler, changelogService changelogService, cmd *cobra.Command, args []string) {
+	content, err := changelogService.Generate()
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	output, _ := cmd.Flags().GetString("output")
+
+	if err := os.WriteFile(output, []byte(content), 0644); err != nil {
+		msgHandler.errorFatalStr("can't write changelog to %s : %s", output, err)
+	}
+
+	msgHandler.success("changelog written to %s", output)
+}
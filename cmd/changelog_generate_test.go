@@ -0,0 +1,108 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestChangelogGenerate(t *testing.T) {
+	dir := t.TempDir()
+
+	type scenario struct {
+		name                string
+		flags               []string
+		getChangelogService func() changelogService
+		test                func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"Failure occurred when generating the changelog",
+			[]string{},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}, err: fmt.Errorf("can't fetch tags")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch tags\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["Generate"])
+			},
+		},
+		{
+			"Changelog can't be written",
+			[]string{"--output", filepath.Join(dir, "missing", "CHANGELOG.md")},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}, content: "# Changelog\n"}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Contains(t, stderr.String(), "can't write changelog to "+filepath.Join(dir, "missing", "CHANGELOG.md"))
+			},
+		},
+		{
+			"Generate the changelog",
+			[]string{"--output", filepath.Join(dir, "CHANGELOG.md")},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}, content: "# Changelog\n"}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "changelog written to "+filepath.Join(dir, "CHANGELOG.md")+"\n", stdout.String())
+
+				content, err := os.ReadFile(filepath.Join(dir, "CHANGELOG.md"))
+				assert.NoError(t, err)
+				assert.Equal(t, "# Changelog\n", string(content))
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			changelogService := scenario.getChangelogService()
+			cmd := &cobra.Command{}
+			cmd.Flags().String("output", "CHANGELOG.md", "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				changelogGenerate(msgHandler, changelogService, cmd, []string{})
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, changelogService.(changelogServiceMock).methodCallCount)
+		})
+	}
+}
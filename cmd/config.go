@@ -0,0 +1,174 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+)
+
+// configName is the name of the configuration file without its extension
+const configName = ".versem"
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:         "config",
+	Short:       "Inspect the configuration file, no github access is needed",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.Help())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+}
+
+func setupConfigCmdFunc(f func(messageHandler, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, cmd, args)
+	}
+}
+
+// loadConfig reads the configuration file given with --config, or the one
+// of the repository root which is optional
+func loadConfig(file string, root string) error {
+	if file == "" {
+		for _, ext := range viper.SupportedExts {
+			if _, err := os.Stat(filepath.Join(root, configName+"."+ext)); err == nil {
+				file = filepath.Join(root, configName+"."+ext)
+				break
+			}
+		}
+	}
+
+	if file == "" {
+		return nil
+	}
+
+	viper.SetConfigFile(file)
+	viper.SetConfigType(strings.TrimPrefix(filepath.Ext(file), "."))
+
+	if err := viper.ReadInConfig(); err != nil {
+		return fmt.Errorf("can't read configuration file : %s", err)
+	}
+
+	return nil
+}
+
+// findRepositoryRoot returns the closest directory containing .git
+// from a directory, or the directory itself when there is none
+func findRepositoryRoot(dir string) string {
+	for d := dir; ; d = filepath.Dir(d) {
+		if _, err := os.Stat(filepath.Join(d, ".git")); err == nil {
+			return d
+		}
+
+		if filepath.Dir(d) == d {
+			return dir
+		}
+	}
+}
+
+// applyCommandConfig sets the flags of a command which are not given on the
+// command line from the configuration file, they are defined under the path
+// of the command, like release.create.branch for the --branch flag of release create
+func applyCommandConfig(cmd *cobra.Command) error {
+	path := strings.Fields(cmd.CommandPath())
+	if len(path) < 2 {
+		return nil
+	}
+
+	prefix := strings.Join(path[1:], ".")
+
+	var err error
+
+	cmd.LocalFlags().VisitAll(func(f *pflag.Flag) {
+		key := prefix + "." + f.Name
+		if err != nil || f.Changed || !viper.IsSet(key) {
+			return
+		}
+
+		values := []string{viper.GetString(key)}
+		if _, ok := f.Value.(pflag.SliceValue); ok {
+			values = viper.GetStringSlice(key)
+		}
+
+		for _, value := range values {
+			if e := f.Value.Set(value); e != nil {
+				err = fmt.Errorf("%s is not a valid value for %s : %s", value, key, e)
+				return
+			}
+		}
+	})
+
+	return err
+}
+
+// checkConfigKeys refuses a configuration file defining settings
+// unknown to versem, config validate reports their position
+func checkConfigKeys(file string, schema map[string]string) error {
+	if file == "" {
+		return nil
+	}
+
+	entries, err := readConfigEntries(file)
+	if err != nil {
+		return err
+	}
+
+	unknown := []string{}
+
+	for _, entry := range entries {
+		if _, ok := schema[entry.key]; !ok {
+			unknown = append(unknown, entry.key)
+		}
+	}
+
+	if len(unknown) > 0 {
+		return fmt.Errorf("unknown settings %s in %s, run config validate to check the file", strings.Join(unknown, ", "), file)
+	}
+
+	return nil
+}
+
+// getConfigSchema returns the keys allowed in the configuration file with
+// the type of their value, commands accept a key for each of their flags
+func getConfigSchema(root *cobra.Command) map[string]string {
+	schema := map[string]string{
+		ownerKey:         "string",
+		repositoryKey:    "string",
+		tokenKey:         "string",
+		webhookSecretKey: "string",
+	}
+
+	root.PersistentFlags().VisitAll(func(f *pflag.Flag) {
+		if f.Name != "config" {
+			schema[f.Name] = f.Value.Type()
+		}
+	})
+
+	var walk func(*cobra.Command)
+	walk = func(cmd *cobra.Command) {
+		for _, sub := range cmd.Commands() {
+			prefix := strings.Join(strings.Fields(sub.CommandPath())[1:], ".")
+
+			sub.LocalFlags().VisitAll(func(f *pflag.Flag) {
+				if f.Name != "help" {
+					schema[prefix+"."+f.Name] = f.Value.Type()
+				}
+			})
+
+			walk(sub)
+		}
+	}
+
+	walk(root)
+
+	return schema
+}
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+// redacted replaces secrets in config show
+const redacted = "********"
+
+var configShowCmd = &cobra.Command{
+	Use:         "show",
+	Short:       "Print the effective configuration with the source of every value : flag, environment variable, file or default",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupConfigCmdFunc(configShow),
+}
+
+func init() {
+	configCmd.AddCommand(configShowCmd)
+}
+
+func configShow(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	schema := getConfigSchema(rootCmd)
+
+	keys := []string{repoKey, ownerKey, repositoryKey, tokenKey, webhookSecretKey, schemeKey, zeroMajorAsMinorKey, initialVersionKey, leadingVKey, tagTemplateKey}
+	commandKeys := []string{}
+
+	for _, key := range viper.AllKeys() {
+		if _, ok := configEnvs[key]; !ok && key != repoKey && viper.InConfig(key) {
+			commandKeys = append(commandKeys, key)
+		}
+	}
+
+	sort.Strings(commandKeys)
+
+	for _, key := range append(keys, commandKeys...) {
+		value := viper.GetString(key)
+		if kind := schema[key]; strings.HasSuffix(kind, "Array") || strings.HasSuffix(kind, "Slice") {
+			value = strings.Join(viper.GetStringSlice(key), ",")
+		}
+
+		if (key == tokenKey || key == webhookSecretKey) && value != "" {
+			value = redacted
+		}
+
+		msgHandler.success("%s = %s (%s)", key, value, getConfigSource(key))
+	}
+}
+
+// getConfigSource tells where the effective value of a key comes from,
+// following viper precedence : a changed flag bound to the key, the non
+// empty environment variable bound to the key, the file and the default
+func getConfigSource(key string) string {
+	if f := rootCmd.PersistentFlags().Lookup(key); f != nil && f.Changed {
+		return "flag --" + key
+	}
+
+	if env, ok := configEnvs[key]; ok {
+		if value, ok := os.LookupEnv(env); ok && value != "" {
+			return "env " + env
+		}
+	}
+
+	if viper.InConfig(key) {
+		return "file " + viper.ConfigFileUsed()
+	}
+
+	return "default"
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConfigShow(t *testing.T) {
+	defer resetConfig()
+
+	for _, env := range configEnvs {
+		if value, ok := os.LookupEnv(env); ok {
+			defer os.Setenv(env, value)
+			os.Unsetenv(env)
+		}
+	}
+
+	dir := t.TempDir()
+	file := filepath.Join(dir, ".versem.yaml")
+	assert.NoError(t, os.WriteFile(file, []byte("github:\n  owner: antham\n  token: secret\nscheme: semver\nrelease:\n  create:\n    branch: main\n    component:\n      - api:api/:api/**\n      - cli:cli-:cli/**\n"), 0600))
+	assert.NoError(t, loadConfig("", dir))
+
+	os.Setenv(githubRepository, "versem")
+	defer os.Unsetenv(githubRepository)
+
+	os.Setenv(versemTagTemplate, "")
+	defer os.Unsetenv(versemTagTemplate)
+
+	os.Setenv("REPO", "antham/other")
+	defer os.Unsetenv("REPO")
+
+	assert.NoError(t, rootCmd.PersistentFlags().Set("leading-v", "false"))
+	defer func() {
+		assert.NoError(t, rootCmd.PersistentFlags().Set("leading-v", ""))
+		rootCmd.PersistentFlags().Lookup("leading-v").Changed = false
+	}()
+
+	var stdout bytes.Buffer
+	var stderr bytes.Buffer
+
+	msgHandler := messageHandler{
+		func(exitCode int) {
+			panic(exitCode)
+		},
+		&stdout,
+		&stderr,
+	}
+
+	configShow(msgHandler, &cobra.Command{}, []string{})
+
+	assert.Equal(t, `repo =  (default)
+github.owner = antham (file `+file+`)
+github.repository = versem (env GITHUB_REPOSITORY)
+github.token = ******** (file `+file+`)
+github.webhook-secret =  (default)
+scheme = semver (file `+file+`)
+zero-major-as-minor = false (default)
+initial-version =  (default)
+leading-v = false (flag --leading-v)
+tag-template =  (default)
+release.create.branch = main (file `+file+`)
+release.create.component = api:api/:api/**,cli:cli-:cli/** (file `+file+`)
+`, stdout.String())
+	assert.Equal(t, "", stderr.String())
+}
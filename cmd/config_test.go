@@ -0,0 +1,237 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+)
+
+func resetConfig() {
+	viper.SetConfigType("json")
+	_ = viper.ReadConfig(strings.NewReader("{}"))
+}
+
+func TestLoadConfig(t *testing.T) {
+	defer resetConfig()
+
+	scenarios := []struct {
+		name  string
+		setup func(dir string) string
+		test  func(error)
+	}{
+		{
+			"No configuration file in the repository",
+			func(dir string) string {
+				return ""
+			},
+			func(err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "semver", viper.GetString(schemeKey))
+			},
+		},
+		{
+			"Load the yaml configuration file of the repository",
+			func(dir string) string {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, ".versem.yaml"), []byte("scheme: calver:YYYY.0M.MICRO\ngithub:\n  owner: antham\nrelease:\n  create:\n    branch: main\n"), 0600))
+				return ""
+			},
+			func(err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "calver:YYYY.0M.MICRO", viper.GetString(schemeKey))
+				assert.Equal(t, "antham", viper.GetString(ownerKey))
+				assert.Equal(t, "main", viper.GetString("release.create.branch"))
+			},
+		},
+		{
+			"Environment variables override the configuration file",
+			func(dir string) string {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, ".versem.json"), []byte(`{"tag-template": "release-{version}"}`), 0600))
+				os.Setenv("VERSEM_TAG_TEMPLATE", "{version}-stable")
+				return ""
+			},
+			func(err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "{version}-stable", viper.GetString(tagTemplateKey))
+				os.Unsetenv("VERSEM_TAG_TEMPLATE")
+				assert.Equal(t, "release-{version}", viper.GetString(tagTemplateKey))
+			},
+		},
+		{
+			"Load a toml configuration file given as argument",
+			func(dir string) string {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, "versem.toml"), []byte("leading-v = \"false\"\n"), 0600))
+				return filepath.Join(dir, "versem.toml")
+			},
+			func(err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "false", viper.GetString(leadingVKey))
+			},
+		},
+		{
+			"Configuration file given as argument doesn't exist",
+			func(dir string) string {
+				return filepath.Join(dir, "versem.yaml")
+			},
+			func(err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "can't read configuration file : ")
+			},
+		},
+		{
+			"Configuration file is not valid",
+			func(dir string) string {
+				assert.NoError(t, os.WriteFile(filepath.Join(dir, ".versem.yml"), []byte("scheme: [semver"), 0600))
+				return ""
+			},
+			func(err error) {
+				assert.Error(t, err)
+				assert.Contains(t, err.Error(), "can't read configuration file : ")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			resetConfig()
+			dir := t.TempDir()
+			file := scenario.setup(dir)
+			scenario.test(loadConfig(file, dir))
+		})
+	}
+}
+
+func TestInitConfig(t *testing.T) {
+	defer resetConfig()
+
+	os.Setenv("SCHEME", "bogus")
+	os.Setenv("REPO", "antham/other")
+	defer os.Unsetenv("SCHEME")
+	defer os.Unsetenv("REPO")
+
+	initConfig(newMessageHandler())()
+
+	assert.Equal(t, "semver", viper.GetString(schemeKey))
+	assert.Empty(t, viper.GetString(repoKey))
+}
+
+func TestFindRepositoryRoot(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "repository", ".git"), 0700))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "repository", "cmd", "versem"), 0700))
+
+	assert.Equal(t, filepath.Join(dir, "repository"), findRepositoryRoot(filepath.Join(dir, "repository", "cmd", "versem")))
+	assert.Equal(t, filepath.Join(dir, "repository"), findRepositoryRoot(filepath.Join(dir, "repository")))
+	assert.Equal(t, dir, findRepositoryRoot(dir))
+}
+
+func TestApplyCommandConfig(t *testing.T) {
+	defer resetConfig()
+
+	newCommand := func() *cobra.Command {
+		root := &cobra.Command{Use: "versem"}
+		release := &cobra.Command{Use: "release"}
+		create := &cobra.Command{Use: "create"}
+		create.Flags().String("branch", "", "")
+		create.Flags().Bool("draft", false, "")
+		create.Flags().StringArray("component", []string{}, "")
+		root.AddCommand(release)
+		release.AddCommand(create)
+
+		return create
+	}
+
+	scenarios := []struct {
+		name   string
+		config string
+		flags  []string
+		test   func(*cobra.Command, error)
+	}{
+		{
+			"Flags are set from the configuration file",
+			"release:\n  create:\n    branch: main\n    draft: true\n    component:\n      - api:api/:api/**\n      - cli:cli-:cli/**\n",
+			[]string{},
+			func(cmd *cobra.Command, err error) {
+				assert.NoError(t, err)
+				branch, _ := cmd.Flags().GetString("branch")
+				assert.Equal(t, "main", branch)
+				draft, _ := cmd.Flags().GetBool("draft")
+				assert.True(t, draft)
+				components, _ := cmd.Flags().GetStringArray("component")
+				assert.Equal(t, []string{"api:api/:api/**", "cli:cli-:cli/**"}, components)
+			},
+		},
+		{
+			"Flags override the configuration file",
+			"release:\n  create:\n    branch: main\n    draft: true\n",
+			[]string{"--branch", "release", "--draft=false"},
+			func(cmd *cobra.Command, err error) {
+				assert.NoError(t, err)
+				branch, _ := cmd.Flags().GetString("branch")
+				assert.Equal(t, "release", branch)
+				draft, _ := cmd.Flags().GetBool("draft")
+				assert.False(t, draft)
+			},
+		},
+		{
+			"Value of the configuration file is not valid",
+			"release:\n  create:\n    draft: sometimes\n",
+			[]string{},
+			func(cmd *cobra.Command, err error) {
+				assert.EqualError(t, err, `sometimes is not a valid value for release.create.draft : strconv.ParseBool: parsing "sometimes": invalid syntax`)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			viper.SetConfigType("yaml")
+			assert.NoError(t, viper.ReadConfig(strings.NewReader(scenario.config)))
+
+			cmd := newCommand()
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+			scenario.test(cmd, applyCommandConfig(cmd))
+		})
+	}
+}
+
+func TestCheckConfigKeys(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		content string
+		test    func(file string, err error)
+	}{
+		{
+			"Settings are known",
+			"scheme: semver\nrelease:\n  create:\n    branch: main\n",
+			func(file string, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			"Settings are unknown",
+			"scheme: semver\nbackend: gitlab\nrelease:\n  create:\n    brnch: main\n",
+			func(file string, err error) {
+				assert.EqualError(t, err, "unknown settings backend, release.create.brnch in "+file+", run config validate to check the file")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			file := filepath.Join(t.TempDir(), ".versem.yaml")
+			assert.NoError(t, os.WriteFile(file, []byte(scenario.content), 0600))
+
+			scenario.test(file, checkConfigKeys(file, getConfigSchema(rootCmd)))
+		})
+	}
+
+	assert.NoError(t, checkConfigKeys("", getConfigSchema(rootCmd)))
+}
@@ -0,0 +1,315 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"gopkg.in/yaml.v3"
+)
+
+var configValidateCmd = &cobra.Command{
+	Use:         "validate",
+	Short:       "Check the configuration file, unknown settings and invalid values are reported with their position",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupConfigCmdFunc(configValidate),
+}
+
+func init() {
+	configCmd.AddCommand(configValidateCmd)
+}
+
+// configEntry is a setting of the configuration file, the position
+// is only known for yaml and json files
+type configEntry struct {
+	key    string
+	value  interface{}
+	line   int
+	column int
+}
+
+// configValidators check the values of settings, they are picked
+// from the last part of the key so every command shares them
+var configValidators = map[string]func(string) error{
+	repoKey: func(value string) error {
+		_, _, err := parseRepository(value)
+		return err
+	},
+	schemeKey: func(value string) error {
+		_, err := github.NewSchemeFromString(value)
+		return err
+	},
+	leadingVKey: func(value string) error {
+		if _, err := strconv.ParseBool(value); err != nil {
+			return fmt.Errorf("%s is not a valid leading v setting, use true or false", value)
+		}
+		return nil
+	},
+	tagTemplateKey: func(value string) error {
+		_, err := github.NewTagFormat(value, nil)
+		return err
+	},
+	initialVersionKey: func(value string) error {
+		_, err := github.NewTagFromString(value)
+		return err
+	},
+	"component": func(value string) error {
+		_, err := github.NewComponentFromString(value)
+		return err
+	},
+	"bump-file": func(value string) error {
+		_, err := github.NewFileUpdaterFromString(value)
+		return err
+	},
+	"tag-message": func(value string) error {
+		if _, err := template.New("tag-message").Parse(value); err != nil {
+			return fmt.Errorf("tag message is not a valid template : %s", err)
+		}
+		return nil
+	},
+	"build-metadata": func(value string) error {
+		if _, err := template.New("build-metadata").Parse(value); err != nil {
+			return fmt.Errorf("build metadata template is not valid : %s", err)
+		}
+		return nil
+	},
+}
+
+func configValidate(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	file := viper.ConfigFileUsed()
+	if file == "" {
+		msgHandler.errorFatalStr("no configuration file found, add %s.yaml at the root of the repository or use --config", configName)
+	}
+
+	problems, err := validateConfig(file, getConfigSchema(rootCmd))
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	for _, problem := range problems {
+		msgHandler.warning("%s", problem)
+	}
+
+	if len(problems) > 0 {
+		msgHandler.errorFatalStr("%d errors found in %s", len(problems), file)
+	}
+
+	msgHandler.success("%s is valid", file)
+}
+
+// validateConfig checks every setting of a configuration file against
+// the schema and returns the problems found, prefixed with their position
+func validateConfig(file string, schema map[string]string) ([]string, error) {
+	entries, err := readConfigEntries(file)
+	if err != nil {
+		return nil, err
+	}
+
+	problems := []string{}
+
+	for _, entry := range entries {
+		position := file
+		if entry.line > 0 {
+			position = fmt.Sprintf("%s:%d:%d", file, entry.line, entry.column)
+		}
+
+		for _, err := range validateConfigEntry(entry, schema) {
+			problems = append(problems, fmt.Sprintf("%s : %s : %s", position, entry.key, err))
+		}
+	}
+
+	return problems, nil
+}
+
+func validateConfigEntry(entry configEntry, schema map[string]string) []error {
+	kind, ok := schema[entry.key]
+	if !ok {
+		return []error{fmt.Errorf("unknown setting")}
+	}
+
+	values, err := getConfigValues(kind, entry.value)
+	if err != nil {
+		return []error{err}
+	}
+
+	name := entry.key[strings.LastIndex(entry.key, ".")+1:]
+	errs := []error{}
+
+	for _, value := range values {
+		var err error
+
+		switch kind {
+		case "bool":
+			if _, e := strconv.ParseBool(value); e != nil {
+				err = fmt.Errorf("%s is not a boolean", value)
+			}
+		case "int":
+			if _, e := strconv.Atoi(value); e != nil {
+				err = fmt.Errorf("%s is not a number", value)
+			}
+		}
+
+		if validator, ok := configValidators[name]; ok && err == nil {
+			err = validator(value)
+		}
+
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if name == "component" && len(errs) == 0 {
+		components := []github.Component{}
+
+		for _, value := range values {
+			c, _ := github.NewComponentFromString(value)
+			components = append(components, c)
+		}
+
+		if err := github.CheckComponents(components); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	return errs
+}
+
+// getConfigValues turns the value of a setting into strings,
+// only array settings accept a list
+func getConfigValues(kind string, value interface{}) ([]string, error) {
+	if value == nil {
+		return []string{}, nil
+	}
+
+	list, isList := value.([]interface{})
+
+	if !strings.HasSuffix(kind, "Array") && !strings.HasSuffix(kind, "Slice") {
+		if isList {
+			return nil, fmt.Errorf("must be a single value, not a list")
+		}
+
+		return []string{fmt.Sprint(value)}, nil
+	}
+
+	if !isList {
+		return []string{fmt.Sprint(value)}, nil
+	}
+
+	values := []string{}
+
+	for _, v := range list {
+		switch v.(type) {
+		case []interface{}, map[string]interface{}:
+			return nil, fmt.Errorf("must be a list of values")
+		}
+
+		values = append(values, fmt.Sprint(v))
+	}
+
+	return values, nil
+}
+
+// readConfigEntries lists the settings of a configuration file, yaml
+// and json files are parsed to keep the position of every setting
+func readConfigEntries(file string) ([]configEntry, error) {
+	ext := strings.ToLower(strings.TrimPrefix(filepath.Ext(file), "."))
+
+	if ext != "yaml" && ext != "yml" && ext != "json" {
+		v := viper.New()
+		v.SetConfigFile(file)
+		v.SetConfigType(ext)
+
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("can't read configuration file : %s", err)
+		}
+
+		return flattenConfig(v.AllSettings(), ""), nil
+	}
+
+	content, err := os.ReadFile(file)
+	if err != nil {
+		return nil, fmt.Errorf("can't read configuration file : %s", err)
+	}
+
+	node := yaml.Node{}
+	if err := yaml.Unmarshal(content, &node); err != nil {
+		return nil, fmt.Errorf("can't read configuration file : %s", err)
+	}
+
+	if len(node.Content) == 0 {
+		return []configEntry{}, nil
+	}
+
+	if node.Content[0].Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("can't read configuration file : settings must be defined as a mapping")
+	}
+
+	return walkConfigNode(node.Content[0], "")
+}
+
+func walkConfigNode(node *yaml.Node, prefix string) ([]configEntry, error) {
+	entries := []configEntry{}
+
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		k, v := node.Content[i], node.Content[i+1]
+
+		key := strings.ToLower(k.Value)
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		if v.Kind == yaml.MappingNode {
+			children, err := walkConfigNode(v, key)
+			if err != nil {
+				return nil, err
+			}
+
+			entries = append(entries, children...)
+			continue
+		}
+
+		var value interface{}
+		if err := v.Decode(&value); err != nil {
+			return nil, fmt.Errorf("can't read configuration file : %s", err)
+		}
+
+		entries = append(entries, configEntry{key, value, k.Line, k.Column})
+	}
+
+	return entries, nil
+}
+
+func flattenConfig(settings map[string]interface{}, prefix string) []configEntry {
+	keys := []string{}
+	for key := range settings {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	entries := []configEntry{}
+
+	for _, key := range keys {
+		name := key
+		if prefix != "" {
+			name = prefix + "." + key
+		}
+
+		if children, ok := settings[key].(map[string]interface{}); ok {
+			entries = append(entries, flattenConfig(children, name)...)
+			continue
+		}
+
+		entries = append(entries, configEntry{key: name, value: settings[key]})
+	}
+
+	return entries
+}
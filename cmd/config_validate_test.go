@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateConfig(t *testing.T) {
+	scenarios := []struct {
+		name    string
+		file    string
+		content string
+		test    func(file string, problems []string, err error)
+	}{
+		{
+			"Valid yaml configuration file",
+			".versem.yaml",
+			"github:\n  owner: antham\nscheme: semver\nleading-v: true\nrelease:\n  create:\n    branch: main\n    draft: true\n    component:\n      - api:api/:api/**\n      - cli:cli-:cli/**\n",
+			func(file string, problems []string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{}, problems)
+			},
+		},
+		{
+			"Invalid yaml configuration file",
+			".versem.yaml",
+			"github:\n  owner: antham\nleading-v: maybe\ntag-template: release\nrelease:\n  create:\n    branch: [main]\n    draft: sometimes\n    brnch: main\n    tag-message: \"{{ .Tag\"\n    bump-file:\n      - \"regex:VERSION:(\"\n    component:\n      - api:api/:api/**\n      - minor:minor/:minor/**\nlabel:\n  check:\n    component:\n      - api:api/:api/**\n      - api:api2/:api2/**\n",
+			func(file string, problems []string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{
+					file + ":3:1 : leading-v : maybe is not a valid leading v setting, use true or false",
+					file + ":4:1 : tag-template : tag template release must contain {version} once",
+					file + ":7:5 : release.create.branch : must be a single value, not a list",
+					file + ":8:5 : release.create.draft : sometimes is not a boolean",
+					file + ":9:5 : release.create.brnch : unknown setting",
+					file + ":10:5 : release.create.tag-message : tag message is not a valid template : template: tag-message:1: unclosed action",
+					file + ":11:5 : release.create.bump-file : regex:VERSION:( is not a valid file updater, regex kind requires a valid regular expression",
+					file + ":13:5 : release.create.component : component minor conflicts with the minor semver label",
+					file + ":18:5 : label.check.component : component api is defined twice",
+				}, problems)
+			},
+		},
+		{
+			"Invalid json configuration file",
+			".versem.json",
+			"{\n  \"scheme\": \"calver:YY.MM\",\n  \"release\": {\"floating\": {\"move\": \"yes\"}}\n}\n",
+			func(file string, problems []string, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, problems, 2)
+				assert.Contains(t, problems[0], file+":2:3 : scheme : ")
+				assert.Equal(t, file+":3:28 : release.floating.move : yes is not a boolean", problems[1])
+			},
+		},
+		{
+			"Invalid toml configuration file",
+			".versem.toml",
+			"initial-version = \"1.0\"\n[github]\ntokn = \"token\"\n",
+			func(file string, problems []string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{
+					file + " : github.tokn : unknown setting",
+					file + " : initial-version : 1.0 is not a valid semver tag",
+				}, problems)
+			},
+		},
+		{
+			"Configuration file is not a mapping",
+			".versem.yaml",
+			"- scheme\n",
+			func(file string, problems []string, err error) {
+				assert.EqualError(t, err, "can't read configuration file : settings must be defined as a mapping")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			file := filepath.Join(t.TempDir(), scenario.file)
+			assert.NoError(t, os.WriteFile(file, []byte(scenario.content), 0600))
+
+			problems, err := validateConfig(file, getConfigSchema(rootCmd))
+			scenario.test(file, problems, err)
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	defer resetConfig()
+
+	scenarios := []struct {
+		name    string
+		content string
+		test    func(file string, exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}{
+		{
+			"Configuration file is valid",
+			"scheme: semver\n",
+			func(file string, exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, file+" is valid\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Configuration file has errors",
+			"schem: semver\nleading-v: maybe\n",
+			func(file string, exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, file+":1:1 : schem : unknown setting\n"+file+":2:1 : leading-v : maybe is not a valid leading v setting, use true or false\n2 errors found in "+file+"\n", stderr.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			dir := t.TempDir()
+			file := filepath.Join(dir, ".versem.yaml")
+			assert.NoError(t, os.WriteFile(file, []byte(scenario.content), 0600))
+			assert.NoError(t, loadConfig("", dir))
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				configValidate(msgHandler, &cobra.Command{}, []string{})
+			}()
+
+			w.Wait()
+
+			scenario.test(file, code, stdout, stderr)
+		})
+	}
+}
@@ -18,41 +18,203 @@ var labelCheckCmd = &cobra.Command{
 }
 
 func init() {
+	labelCheckCmd.Flags().Bool("comment", false, "create or update a comment on the pull request with the version it would release")
+	labelCheckCmd.Flags().StringArray("component", []string{}, "check the labels of every component touched by the pull request, defined as name:prefix:glob[,glob...], can be repeated")
 	labelCmd.AddCommand(labelCheckCmd)
 }
 
-func setupLabelCheckCmdFunc(f func(messageHandler, semverService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+func setupLabelCheckCmdFunc(f func(messageHandler, semverService, releaseService, commentService, *cobra.Command, []string)) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		msgHandler := newMessageHandler()
-		f(msgHandler, getSemverLabelService(), cmd, args)
+		f(msgHandler, getSemverLabelService(), getReleaseService(), getCommentService(), cmd, args)
 	}
 }
 
-func labelCheck(msgHandler messageHandler, semverService semverService, cmd *cobra.Command, args []string) {
+func labelCheck(msgHandler messageHandler, semverService semverService, releaseService releaseService, commentService commentService, cmd *cobra.Command, args []string) {
 	if len(args) != 1 {
 		msgHandler.errorFatalStr("provide a pull request number or full commit sha as first argument")
 	}
 
-	var version github.Version
-	var err error
-	var successMsg = "%s semver version found"
+	var pullRequestNumber int
+	var ref string
 
 	switch {
 	case regexp.MustCompile("[0-9a-f]{40}").MatchString(args[0]):
+		ref = args[0]
+	case regexp.MustCompile("[0-9]+").MatchString(args[0]):
+		pullRequestNumber, _ = strconv.Atoi(args[0])
+		ref = fmt.Sprintf("refs/pull/%d/head", pullRequestNumber)
+	default:
+		msgHandler.errorFatalStr("%s is not a valid number, nor a valid commit sha", args[0])
+	}
+
+	if comment, _ := cmd.Flags().GetBool("comment"); comment && pullRequestNumber == 0 {
+		msgHandler.errorFatalStr("--comment requires a pull request number, a commit sha can't be commented")
+	}
+
+	components, err := getComponents(cmd)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if len(components) > 0 {
+		labelCheckComponents(msgHandler, semverService, releaseService, commentService, cmd, args[0], pullRequestNumber, ref, components)
+		return
+	}
+
+	var version github.Version
+	var successMsg = "%s semver version found"
+
+	if pullRequestNumber != 0 {
+		version, err = semverService.GetFromPullRequest(pullRequestNumber)
+	} else {
 		version, err = semverService.GetFromCommit(args[0])
 		if version == github.NORELEASE {
 			successMsg += fmt.Sprintf(" or commit %s is not tied to a pull request", args[0])
 		}
-	case regexp.MustCompile("[0-9]+").MatchString(args[0]):
-		n, _ := strconv.Atoi(args[0])
-		version, err = semverService.GetFromPullRequest(n)
-	default:
-		err = fmt.Errorf("%s is not a valid number, nor a valid commit sha", args[0])
 	}
 
 	if err != nil {
 		msgHandler.errorFatal(err)
 	}
 
+	comment, _ := cmd.Flags().GetBool("comment")
+	lastTag, nextTag := getLabelCheckNext(msgHandler, releaseService, version, nil, ref, comment)
+
+	if comment {
+		body := buildVersionComment([]versionCommentRow{{"", version, lastTag, nextTag}})
+
+		if err := commentService.Upsert(pullRequestNumber, body); err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+
 	msgHandler.success(successMsg, strings.ToLower(version.String()))
 }
+
+// labelCheckComponents ensures every component touched by a pull request gets exactly one version
+func labelCheckComponents(msgHandler messageHandler, semverService semverService, releaseService releaseService, commentService commentService, cmd *cobra.Command, arg string, pullRequestNumber int, ref string, components []github.Component) {
+	var versions []github.ComponentVersion
+	var err error
+
+	if pullRequestNumber != 0 {
+		versions, err = semverService.GetComponentsFromPullRequest(pullRequestNumber, components)
+	} else {
+		versions, err = semverService.GetComponentsFromCommit(arg, components)
+	}
+
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if len(versions) == 0 {
+		msgHandler.success("no component touched by %s", arg)
+		return
+	}
+
+	comment, _ := cmd.Flags().GetBool("comment")
+	rows := []versionCommentRow{}
+
+	for i := range versions {
+		lastTag, nextTag := getLabelCheckNext(msgHandler, releaseService, versions[i].Version, &versions[i].Component, ref, comment)
+
+		rows = append(rows, versionCommentRow{versions[i].Component.Name, versions[i].Version, lastTag, nextTag})
+	}
+
+	if comment {
+		if err := commentService.Upsert(pullRequestNumber, buildVersionComment(rows)); err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+
+	for _, v := range versions {
+		msgHandler.success("%s semver version found for component %s", strings.ToLower(v.Version.String()), v.Component.Name)
+	}
+}
+
+// versionCommentRow describes the version released for a component,
+// the component is empty when the repository is released as a whole
+type versionCommentRow struct {
+	component string
+	version   github.Version
+	lastTag   *github.Tag
+	nextTag   github.Tag
+}
+
+func buildVersionComment(rows []versionCommentRow) string {
+	b := strings.Builder{}
+
+	if rows[0].component != "" {
+		b.WriteString("| Component | Label | Latest tag | Next version |\n| --- | --- | --- | --- |\n")
+	} else {
+		b.WriteString("| Label | Latest tag | Next version |\n| --- | --- | --- |\n")
+	}
+
+	for _, row := range rows {
+		latest := "none"
+		if row.lastTag != nil {
+			latest = row.lastTag.String()
+		}
+
+		next := row.nextTag.String()
+		if row.version == github.NORELEASE {
+			next = "no release"
+		}
+
+		if row.component != "" {
+			b.WriteString(fmt.Sprintf("| %s ", row.component))
+		}
+
+		b.WriteString(fmt.Sprintf("| %s | %s | %s |\n", strings.ToLower(row.version.String()), latest, next))
+	}
+
+	return b.String()
+}
+
+// getLabelCheckNext computes the latest and the next tags of a version, they're only
+// required to comment the pull request, otherwise they're computed for a major
+// version to refuse it when the go module path doesn't allow the release
+func getLabelCheckNext(msgHandler messageHandler, releaseService releaseService, version github.Version, component *github.Component, ref string, required bool) (*github.Tag, github.Tag) {
+	if !required && version != github.MAJOR {
+		return nil, github.Tag{}
+	}
+
+	lastTag, nextTag, err := releaseService.GetNext(version, component)
+	if err != nil {
+		if required {
+			msgHandler.errorFatal(err)
+		}
+
+		msgHandler.warning("%s", err)
+		return nil, github.Tag{}
+	}
+
+	if version == github.MAJOR {
+		checkGoModule(msgHandler, releaseService, version, lastTag, nextTag, ref)
+	}
+
+	return lastTag, nextTag
+}
+
+// checkGoModule ensures the go module path matches the tag released from ref,
+// the latest tag is checked when nothing is released
+func checkGoModule(msgHandler messageHandler, releaseService releaseService, version github.Version, lastTag *github.Tag, nextTag github.Tag, ref string) {
+	tag := nextTag
+
+	if version == github.NORELEASE {
+		if lastTag == nil {
+			return
+		}
+
+		tag = *lastTag
+	}
+
+	warning, err := releaseService.CheckGoModule(version, tag, ref)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if warning != "" {
+		msgHandler.warning(warning)
+	}
+}
@@ -14,10 +14,12 @@ import (
 
 func TestLabelCheck(t *testing.T) {
 	type scenario struct {
-		name             string
-		getSemverService func() semverService
-		getArgument      func() []string
-		test             func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+		name              string
+		getSemverService  func() semverService
+		getReleaseService func() releaseService
+		getArgument       func() []string
+		comment           bool
+		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string)
 	}
 
 	scenarios := []scenario{
@@ -26,10 +28,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 1, exitCode)
 				assert.Equal(t, "provide a pull request number or full commit sha as first argument\n", stderr.String())
 				assert.Len(t, methodCallCount, 0)
@@ -40,10 +46,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{"test"}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 1, exitCode)
 				assert.Equal(t, "test is not a valid number, nor a valid commit sha\n", stderr.String())
 				assert.Len(t, methodCallCount, 0)
@@ -54,10 +64,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{err: fmt.Errorf("failure occurred when calling fetching label from commit"), methodCallCount: map[string]int{}}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{"8a5ed8235d18fb0243493b82baf5d988459d24db"}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 1, exitCode)
 				assert.Equal(t, "failure occurred when calling fetching label from commit\n", stderr.String())
 				assert.Len(t, methodCallCount, 1)
@@ -69,10 +83,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{err: fmt.Errorf("failure occurred when calling fetching label from pull request"), methodCallCount: map[string]int{}}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{"123"}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 1, exitCode)
 				assert.Equal(t, "failure occurred when calling fetching label from pull request\n", stderr.String())
 				assert.Len(t, methodCallCount, 1)
@@ -84,10 +102,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{"123"}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 0, exitCode)
 				assert.Empty(t, stderr.String())
 				assert.Equal(t, "minor semver version found\n", stdout.String())
@@ -100,10 +122,14 @@ func TestLabelCheck(t *testing.T) {
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}, version: github.NORELEASE}
 			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
 			func() []string {
 				return []string{"8a5ed8235d18fb0243493b82baf5d988459d24db"}
 			},
-			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
 				assert.Equal(t, 0, exitCode)
 				assert.Empty(t, stderr.String())
 				assert.Equal(t, "norelease semver version found or commit 8a5ed8235d18fb0243493b82baf5d988459d24db is not tied to a pull request\n", stdout.String())
@@ -111,6 +137,152 @@ func TestLabelCheck(t *testing.T) {
 				assert.Equal(t, 1, methodCallCount["GetFromCommit"])
 			},
 		},
+		{
+			"Create comment with next version on pull request",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, lastTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2, Patch: 3}, tag: github.Tag{LeadingV: true, Major: 1, Minor: 3}}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Empty(t, stderr.String())
+				assert.Equal(t, "minor semver version found\n", stdout.String())
+				assert.Equal(t, "| Label | Latest tag | Next version |\n| --- | --- | --- |\n| minor | v1.2.3 | v1.3.0 |\n", comment)
+			},
+		},
+		{
+			"Create comment on pull request with norelease label and no tag",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.NORELEASE}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true}}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Empty(t, stderr.String())
+				assert.Equal(t, "| Label | Latest tag | Next version |\n| --- | --- | --- |\n| norelease | none | no release |\n", comment)
+			},
+		},
+		{
+			"Failure occurred when computing next version",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, nextErr: fmt.Errorf("can't fetch latest tag")}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch latest tag\n", stderr.String())
+				assert.Empty(t, comment)
+			},
+		},
+		{
+			"Go module path doesn't allow a major release",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MAJOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true, Major: 2}, goModuleErr: fmt.Errorf("module path github.com/antham/versem in go.mod must end with /v2 to release v2.0.0")}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "module path github.com/antham/versem in go.mod must end with /v2 to release v2.0.0\n", stderr.String())
+				assert.Empty(t, stdout.String())
+			},
+		},
+		{
+			"Go module path doesn't match the released major tag",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MAJOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true, Major: 3}, goModuleWarning: "module path github.com/antham/versem/v2 in go.mod doesn't match tag v3.0.0, it should end with /v3"}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "module path github.com/antham/versem/v2 in go.mod doesn't match tag v3.0.0, it should end with /v3\n", stderr.String())
+				assert.Equal(t, "major semver version found\n", stdout.String())
+			},
+		},
+		{
+			"Go module path isn't checked without a major release",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.PATCH}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true, Major: 1, Patch: 1}, goModuleWarning: "module path github.com/antham/versem/v2 in go.mod has a /v2 suffix while tag v1.0.1 is a v1 version"}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Empty(t, stderr.String())
+				assert.Equal(t, "patch semver version found\n", stdout.String())
+			},
+		},
+		{
+			"Failure occurred when computing next version of a major release without comment",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MAJOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, nextErr: fmt.Errorf("can't fetch latest tag")}
+			},
+			func() []string {
+				return []string{"123"}
+			},
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "can't fetch latest tag\n", stderr.String())
+				assert.Equal(t, "major semver version found\n", stdout.String())
+			},
+		},
+		{
+			"Refuse to comment when a commit is given",
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() []string {
+				return []string{"8a5ed8235d18fb0243493b82baf5d988459d24db"}
+			},
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--comment requires a pull request number, a commit sha can't be commented\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+				assert.Empty(t, comment)
+			},
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -133,6 +305,12 @@ func TestLabelCheck(t *testing.T) {
 			w.Add(1)
 
 			semverService := scenario.getSemverService()
+			releaseService := scenario.getReleaseService()
+			var comment string
+			commentService := commentServiceMock{body: &comment, methodCallCount: map[string]int{}}
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("comment", scenario.comment, "")
+			cmd.Flags().StringArray("component", []string{}, "")
 
 			go func() {
 				defer func() {
@@ -143,13 +321,116 @@ func TestLabelCheck(t *testing.T) {
 					w.Done()
 				}()
 
-				labelCheck(msgHandler, semverService, &cobra.Command{}, scenario.getArgument())
+				labelCheck(msgHandler, semverService, releaseService, commentService, cmd, scenario.getArgument())
 			}()
 
 			w.Wait()
 
-			scenario.test(code, stdout, stderr, semverService.(semverServiceMock).methodCallCount)
+			scenario.test(code, stdout, stderr, semverService.(semverServiceMock).methodCallCount, comment)
 		})
 	}
 
 }
+
+func TestLabelCheckComponents(t *testing.T) {
+	api := github.Component{Name: "api", Prefix: "api/", Paths: []string{"api/**"}}
+	cli := github.Component{Name: "cli", Prefix: "cli-", Paths: []string{"cli/**"}}
+
+	type scenario struct {
+		name             string
+		getSemverService func() semverService
+		argument         string
+		comment          bool
+		test             func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string)
+	}
+
+	scenarios := []scenario{
+		{
+			"Failure occurred when fetching versions of components",
+			func() semverService {
+				return semverServiceMock{err: fmt.Errorf("an error occurred when parsing version of component cli from pull request #123 : no semver label found"), methodCallCount: map[string]int{}}
+			},
+			"123",
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "an error occurred when parsing version of component cli from pull request #123 : no semver label found\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["GetComponentsFromPullRequest"])
+			},
+		},
+		{
+			"No component touched by the commit",
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{}, methodCallCount: map[string]int{}}
+			},
+			"8a5ed8235d18fb0243493b82baf5d988459d24db",
+			false,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "no component touched by 8a5ed8235d18fb0243493b82baf5d988459d24db\n", stdout.String())
+				assert.Equal(t, 1, methodCallCount["GetComponentsFromCommit"])
+				assert.Equal(t, 0, methodCallCount["GetFromCommit"])
+			},
+		},
+		{
+			"Create comment with next version of every touched component",
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{{Component: api, Version: github.MAJOR}, {Component: cli, Version: github.NORELEASE}}, methodCallCount: map[string]int{}}
+			},
+			"123",
+			true,
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int, comment string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Empty(t, stderr.String())
+				assert.Equal(t, "major semver version found for component api\nnorelease semver version found for component cli\n", stdout.String())
+				assert.Equal(t, "| Component | Label | Latest tag | Next version |\n| --- | --- | --- | --- |\n| api | major | api/v1.2.3 | api/v2.0.0 |\n| cli | norelease | api/v1.2.3 | no release |\n", comment)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			semverService := scenario.getSemverService()
+			releaseService := releaseServiceMock{methodCallCount: map[string]int{}, lastTag: &github.Tag{Prefix: "api/", LeadingV: true, Major: 1, Minor: 2, Patch: 3}, tag: github.Tag{Prefix: "api/", LeadingV: true, Major: 2}}
+			var comment string
+			commentService := commentServiceMock{body: &comment, methodCallCount: map[string]int{}}
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("comment", scenario.comment, "")
+			cmd.Flags().StringArray("component", []string{}, "")
+			assert.NoError(t, cmd.ParseFlags([]string{"--component", "api:api/:api/**", "--component", "cli:cli-:cli/**"}))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				labelCheck(msgHandler, semverService, releaseService, commentService, cmd, []string{scenario.argument})
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, semverService.(semverServiceMock).methodCallCount, comment)
+		})
+	}
+}
@@ -38,3 +38,7 @@ func (m messageHandler) errorFatalStr(err string, args ...interface{}) {
 func (m messageHandler) success(str string, args ...interface{}) {
 	failOnFprintError(color.New(color.FgGreen).Fprintf(m.stdoutWriter, str+"\n", args...))
 }
+
+func (m messageHandler) warning(str string, args ...interface{}) {
+	failOnFprintError(color.New(color.FgYellow).Fprintf(m.stderrWriter, str+"\n", args...))
+}
@@ -1,7 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
+	"fmt"
+	"path/filepath"
 	"regexp"
+	"text/template"
+	"time"
 
 	"github.com/antham/versem/github"
 
@@ -15,35 +20,452 @@ var releaseCreateCmd = &cobra.Command{
 }
 
 func init() {
+	releaseCreateCmd.Flags().StringArray("asset", []string{}, "upload files matching this glob pattern to the release, can be repeated")
+	releaseCreateCmd.Flags().Bool("draft", false, "create a draft release, it must be published with release publish")
+	releaseCreateCmd.Flags().Bool("tag-only", false, "create the git tag without any github release")
+	releaseCreateCmd.Flags().Bool("annotated", false, "create an annotated tag instead of a lightweight one")
+	releaseCreateCmd.Flags().String("tag-message", "", "go template of the annotated tag message, it receives .Tag, .Version, .PullRequest (.Number, .Title, .Body) and .Changelog with --changelog")
+	releaseCreateCmd.Flags().String("tagger-name", "", "name of the annotated tag author")
+	releaseCreateCmd.Flags().String("tagger-email", "", "email of the annotated tag author")
+	releaseCreateCmd.Flags().String("sign-key", "", "sign the annotated tag with this gpg key id or ssh private key file")
+	releaseCreateCmd.Flags().String("sign-format", "gpg", "format of the tag signature : gpg or ssh")
+	releaseCreateCmd.Flags().StringArray("bump-file", []string{}, "write the tag in a repository file before tagging, defined as kind:path[:expression] with kind file, regex, json or yaml, can be repeated")
+	releaseCreateCmd.Flags().String("branch", "", "branch receiving the commit of bumped files, default branch of the repository when empty")
+	releaseCreateCmd.Flags().Bool("changelog", false, "prepend the merged pull requests to the changelog file of the repository and use them as release body")
+	releaseCreateCmd.Flags().StringArray("component", []string{}, "release independently the components touched by the pull request, defined as name:prefix:glob[,glob...] with a tag prefix like api/ or cli-, a name:version label sets the version of a component, can be repeated")
+	releaseCreateCmd.Flags().String("build-metadata", "", "go template of build metadata appended to the release name, it receives .ShortSha, .Build and .Date")
+	releaseCreateCmd.Flags().String("build-number", "", "build number of the CI given to the build metadata template as .Build")
+	releaseCreateCmd.Flags().Bool("build-metadata-tag", false, "create an additional tag with the build metadata instead of naming the release with it")
+	releaseCreateCmd.Flags().String("output", "text", "output format : text or json")
+	releaseCreateCmd.Flags().Bool("force", false, "don't skip a commit already carrying a semver tag or release, a tag already on the commit is reused")
 	releaseCmd.AddCommand(releaseCreateCmd)
 }
 
-func setupReleaseCreateCmdFunc(f func(messageHandler, semverService, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+func setupReleaseCreateCmdFunc(f func(messageHandler, semverService, releaseService, changelogService, *cobra.Command, []string)) func(*cobra.Command, []string) {
 	return func(cmd *cobra.Command, args []string) {
 		msgHandler := newMessageHandler()
-		f(msgHandler, getSemverLabelService(), getReleaseService(), cmd, args)
+		f(msgHandler, getSemverLabelService(), getReleaseService(), getChangelogService(), cmd, args)
 	}
 }
 
-func releaseCreate(msgHandler messageHandler, semverService semverService, releaseService releaseService, cmd *cobra.Command, args []string) {
+func releaseCreate(msgHandler messageHandler, semverService semverService, releaseService releaseService, changelogService changelogService, cmd *cobra.Command, args []string) {
 	if len(args) != 1 || !regexp.MustCompile("[0-9a-f]{40}").MatchString(args[0]) {
 		msgHandler.errorFatalStr("provide a full commit sha as first argument")
 	}
 
+	patterns, _ := cmd.Flags().GetStringArray("asset")
+	assets, err := getAssets(patterns)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	options, err := getTagOptions(cmd)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	updaters, _ := cmd.Flags().GetStringArray("bump-file")
+	for _, updater := range updaters {
+		f, err := github.NewFileUpdaterFromString(updater)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		options.FileUpdaters = append(options.FileUpdaters, f)
+	}
+	options.Branch, _ = cmd.Flags().GetString("branch")
+
+	components, err := getComponents(cmd)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if changelog, _ := cmd.Flags().GetBool("changelog"); len(components) > 0 && (len(options.FileUpdaters) > 0 || changelog) {
+		msgHandler.errorFatalStr("--component can't be used with --bump-file or --changelog")
+	}
+
+	draft, _ := cmd.Flags().GetBool("draft")
+	options.Draft = draft || len(assets) > 0
+	options.TagOnly, _ = cmd.Flags().GetBool("tag-only")
+
+	if options.TagOnly && options.Draft {
+		msgHandler.errorFatalStr("--tag-only can't be used with --draft or --asset since no release is created")
+	}
+
+	options.BuildMetadata, options.BuildMetadataTag, err = getBuildMetadata(cmd, args[0])
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if options.TagOnly && options.BuildMetadata != "" && !options.BuildMetadataTag {
+		msgHandler.errorFatalStr("--build-metadata names the release, use --build-metadata-tag with --tag-only")
+	}
+
+	if output, _ := cmd.Flags().GetString("output"); output != "text" && output != "json" {
+		msgHandler.errorFatalStr("%s is not a valid output format, use text or json", output)
+	}
+
+	if len(components) > 0 {
+		releaseComponents(msgHandler, semverService, releaseService, changelogService, cmd, args[0], components, options, assets, draft)
+		return
+	}
+
 	version, err := semverService.GetFromCommit(args[0])
 	if err != nil {
 		msgHandler.errorFatal(err)
 	}
 
 	if version == github.NORELEASE {
-		msgHandler.success("label norelease found or no pull request is attached to %s, skip tag creation", args[0])
+		printSkip(msgHandler, cmd, "label norelease found or no pull request is attached to %s, skip tag creation", args[0])
+		return
+	}
+
+	if options.TagMessage != nil {
+		options.PullRequest, err = semverService.GetPullRequestFromCommit(args[0])
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+
+	createTag(msgHandler, releaseService, changelogService, cmd, version, args[0], options, assets, draft)
+}
+
+// releaseComponents creates a tag for every component touched by the pull request
+// of a commit, with the version given by the labels of the component
+func releaseComponents(msgHandler messageHandler, semverService semverService, releaseService releaseService, changelogService changelogService, cmd *cobra.Command, commitSha string, components []github.Component, options github.ReleaseOptions, assets []string, draft bool) {
+	versions, err := semverService.GetComponentsFromCommit(commitSha, components)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if len(versions) == 0 {
+		printSkip(msgHandler, cmd, "no component touched by commit %s, skip tag creation", commitSha)
+		return
+	}
+
+	if options.TagMessage != nil {
+		options.PullRequest, err = semverService.GetPullRequestFromCommit(commitSha)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+
+	for i := range versions {
+		if versions[i].Version == github.NORELEASE {
+			printSkip(msgHandler, cmd, "label norelease found for component %s, skip tag creation", versions[i].Component.Name)
+			continue
+		}
+
+		options.Component = &versions[i].Component
+		createTag(msgHandler, releaseService, changelogService, cmd, versions[i].Version, commitSha, options, assets, draft)
+	}
+}
+
+// createTag creates the next tag of a commit, scoped to
+// the component of the options when there is one
+func createTag(msgHandler messageHandler, releaseService releaseService, changelogService changelogService, cmd *cobra.Command, version github.Version, commitSha string, options github.ReleaseOptions, assets []string, draft bool) {
+	if force, _ := cmd.Flags().GetBool("force"); !force {
+		commitTag, err := releaseService.GetFromCommit(commitSha, options.Component)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		if commitTag != nil {
+			resumeRelease(msgHandler, releaseService, cmd, version, *commitTag, commitSha, options, assets, draft, fmt.Sprintf("commit %s is already tagged with %s, skip tag creation", commitSha, commitTag))
+			return
+		}
+
+		if changelog, _ := cmd.Flags().GetBool("changelog"); len(options.FileUpdaters) > 0 || changelog {
+			bumpCommitTag, bumpSha := getBumpCommitTag(msgHandler, releaseService, commitSha, options)
+
+			if bumpCommitTag != nil {
+				resumeRelease(msgHandler, releaseService, cmd, version, *bumpCommitTag, bumpSha, options, assets, draft, fmt.Sprintf("commit %s is already released with %s on bump commit %s, skip tag creation", commitSha, bumpCommitTag, bumpSha))
+				return
+			}
+		}
+	}
+
+	lastTag, nextTag, err := releaseService.GetNext(version, options.Component)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	checkGoModule(msgHandler, releaseService, version, lastTag, nextTag, commitSha)
+
+	if changelog, _ := cmd.Flags().GetBool("changelog"); changelog {
+		c, err := changelogService.Get(nextTag, lastTag, commitSha)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		options.Changelog = &c
+	}
+
+	tag, err := releaseService.CreateNext(version, commitSha, options)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	publishRelease(msgHandler, releaseService, cmd, tag, lastTag, commitSha, options, assets, draft, "tag %s created")
+}
+
+// resumeRelease completes the release of a tag left on a commit by a failed run, the
+// missing release is created and the assets of a draft release are uploaded before
+// publishing it, otherwise the commit is skipped, it's skipped as well when no
+// release is expected or when the release requires to bump files again
+func resumeRelease(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, version github.Version, commitTag github.Tag, commitSha string, options github.ReleaseOptions, assets []string, draft bool, skipMsg string) {
+	if options.TagOnly {
+		printSkip(msgHandler, cmd, "%s", skipMsg)
+		return
+	}
+
+	status, err := releaseService.GetReleaseStatus(commitTag)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	changelog, _ := cmd.Flags().GetBool("changelog")
+
+	switch {
+	case status == github.DRAFTED && len(assets) > 0:
+		publishRelease(msgHandler, releaseService, cmd, commitTag, nil, commitSha, options, assets, draft, "assets uploaded to tag %s")
+	case status == github.NOTRELEASED && len(options.FileUpdaters) == 0 && !changelog:
+		tag, err := releaseService.CreateNext(version, commitSha, options)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		publishRelease(msgHandler, releaseService, cmd, tag, nil, commitSha, options, assets, draft, "release of existing tag %s created")
+	default:
+		printSkip(msgHandler, cmd, "%s", skipMsg)
+	}
+}
+
+// publishRelease uploads the assets to the release of a tag and publishes it unless
+// a draft is requested, the release is left as a draft when an upload fails
+func publishRelease(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, tag github.Tag, lastTag *github.Tag, commitSha string, options github.ReleaseOptions, assets []string, draft bool, str string) {
+	if len(assets) > 0 {
+		if err := releaseService.UploadAssets(tag, assets); err != nil {
+			msgHandler.errorFatalStr("%s, release %s is left as a draft", err, tag)
+		}
+	}
+
+	report := newTagReport(tag, lastTag, commitSha, options, draft)
+
+	if draft {
+		printTag(msgHandler, cmd, report, str+" with a draft release", tag)
 		return
 	}
 
-	tag, err := releaseService.CreateNext(version, args[0])
+	if len(assets) > 0 {
+		if err := releaseService.Publish(tag); err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+
+	printTag(msgHandler, cmd, report, str, tag)
+}
+
+// getBumpCommitTag returns the tag of the bump commit created on top of
+// a commit by a previous run, the tag is carried by the bump commit
+func getBumpCommitTag(msgHandler messageHandler, releaseService releaseService, commitSha string, options github.ReleaseOptions) (*github.Tag, string) {
+	bumpSha, err := releaseService.GetBumpCommit(commitSha, options.Branch)
 	if err != nil {
 		msgHandler.errorFatal(err)
 	}
 
-	msgHandler.success("tag %s created", tag)
+	if bumpSha == "" {
+		return nil, ""
+	}
+
+	tag, err := releaseService.GetFromCommit(bumpSha, options.Component)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	return tag, bumpSha
+}
+
+// tagReport describes a created tag in the json output
+type tagReport struct {
+	Tag              string `json:"tag"`
+	Version          string `json:"version"`
+	PreviousTag      string `json:"previousTag,omitempty"`
+	Component        string `json:"component,omitempty"`
+	CommitSha        string `json:"commitSha"`
+	BuildMetadata    string `json:"buildMetadata,omitempty"`
+	BuildMetadataTag string `json:"buildMetadataTag,omitempty"`
+	Release          string `json:"release,omitempty"`
+	Draft            bool   `json:"draft"`
+}
+
+func newTagReport(tag github.Tag, lastTag *github.Tag, commitSha string, options github.ReleaseOptions, draft bool) tagReport {
+	version := tag
+	version.Prefix = ""
+	version.LeadingV = false
+	version.Template = ""
+
+	buildTag := tag
+	buildTag.BuildMetadata = options.BuildMetadata
+
+	report := tagReport{Tag: tag.String(), Version: version.String(), CommitSha: commitSha, BuildMetadata: options.BuildMetadata, Draft: draft}
+
+	if lastTag != nil {
+		report.PreviousTag = lastTag.String()
+	}
+
+	if options.Component != nil {
+		report.Component = options.Component.Name
+	}
+
+	if options.BuildMetadata != "" && options.BuildMetadataTag {
+		report.BuildMetadataTag = buildTag.String()
+	}
+
+	if !options.TagOnly {
+		report.Release = tag.String()
+
+		if options.BuildMetadata != "" && !options.BuildMetadataTag {
+			report.Release = buildTag.String()
+		}
+	}
+
+	return report
+}
+
+// printTag reports a created tag, as json when --output json is used
+func printTag(msgHandler messageHandler, cmd *cobra.Command, report tagReport, str string, args ...interface{}) {
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		b, _ := json.Marshal(report)
+		msgHandler.success("%s", b)
+		return
+	}
+
+	msgHandler.success(str, args...)
+}
+
+// printSkip reports a skipped tag creation, on stderr when --output json
+// is used to keep stdout parsable
+func printSkip(msgHandler messageHandler, cmd *cobra.Command, str string, args ...interface{}) {
+	if output, _ := cmd.Flags().GetString("output"); output == "json" {
+		msgHandler.warning(str, args...)
+		return
+	}
+
+	msgHandler.success(str, args...)
+}
+
+// getBuildMetadata renders the build metadata template of --build-metadata
+// for a commit, the boolean is true when it goes to an additional tag
+func getBuildMetadata(cmd *cobra.Command, commitSha string) (string, bool, error) {
+	tmpl, _ := cmd.Flags().GetString("build-metadata")
+	buildTag, _ := cmd.Flags().GetBool("build-metadata-tag")
+
+	if tmpl == "" {
+		if buildTag {
+			return "", false, fmt.Errorf("--build-metadata-tag requires --build-metadata")
+		}
+
+		return "", false, nil
+	}
+
+	build, _ := cmd.Flags().GetString("build-number")
+
+	metadata, err := github.NewBuildMetadata(tmpl, github.BuildMetadataData{ShortSha: commitSha[:7], Build: build, Date: time.Now().UTC()})
+	if err != nil {
+		return "", false, err
+	}
+
+	return metadata, buildTag, nil
+}
+
+// getTagOptions reads the flags describing the tag, a message,
+// a tagger or a signing key produce an annotated tag
+func getTagOptions(cmd *cobra.Command) (github.ReleaseOptions, error) {
+	options := github.ReleaseOptions{}
+	options.Annotated, _ = cmd.Flags().GetBool("annotated")
+
+	if message, _ := cmd.Flags().GetString("tag-message"); message != "" {
+		t, err := template.New("tag-message").Parse(message)
+		if err != nil {
+			return options, fmt.Errorf("--tag-message is not a valid template : %s", err)
+		}
+
+		options.TagMessage = t
+		options.Annotated = true
+	}
+
+	name, _ := cmd.Flags().GetString("tagger-name")
+	email, _ := cmd.Flags().GetString("tagger-email")
+
+	if (name == "") != (email == "") {
+		return options, fmt.Errorf("--tagger-name and --tagger-email must be defined together")
+	}
+
+	if name != "" {
+		options.Tagger = &github.Tagger{Name: name, Email: email}
+		options.Annotated = true
+	}
+
+	key, _ := cmd.Flags().GetString("sign-key")
+	if key == "" {
+		return options, nil
+	}
+
+	if options.Tagger == nil {
+		return options, fmt.Errorf("--sign-key requires --tagger-name and --tagger-email")
+	}
+
+	switch format, _ := cmd.Flags().GetString("sign-format"); format {
+	case "gpg":
+		options.Signer = github.GPGSigner{KeyID: key}
+	case "ssh":
+		options.Signer = github.SSHSigner{KeyFile: key}
+	default:
+		return options, fmt.Errorf("%s is not a valid signature format, use gpg or ssh", format)
+	}
+
+	options.Annotated = true
+
+	return options, nil
+}
+
+// getComponents parses the components defined with --component
+func getComponents(cmd *cobra.Command) ([]github.Component, error) {
+	components := []github.Component{}
+	definitions, _ := cmd.Flags().GetStringArray("component")
+
+	for _, definition := range definitions {
+		c, err := github.NewComponentFromString(definition)
+		if err != nil {
+			return nil, err
+		}
+
+		components = append(components, c)
+	}
+
+	if err := github.CheckComponents(components); err != nil {
+		return nil, err
+	}
+
+	return components, nil
+}
+
+func getAssets(patterns []string) ([]string, error) {
+	paths := []string{}
+
+	for _, pattern := range patterns {
+		matches, err := filepath.Glob(pattern)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid glob pattern : %s", pattern, err)
+		}
+
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no file matches %s", pattern)
+		}
+
+		paths = append(paths, matches...)
+	}
+
+	return paths, nil
 }
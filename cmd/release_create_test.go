@@ -3,6 +3,8 @@ package cmd
 import (
 	"bytes"
 	"fmt"
+	"os"
+	"path/filepath"
 	"sync"
 	"testing"
 
@@ -14,9 +16,17 @@ import (
 )
 
 func TestReleaseCreate(t *testing.T) {
+	dir := t.TempDir()
+	asset := filepath.Join(dir, "versem_linux_amd64")
+	assert.NoError(t, os.WriteFile(asset, []byte("binary"), 0600))
+
+	var options github.ReleaseOptions
+	var assets []string
+
 	type scenario struct {
 		name              string
 		arguments         []string
+		flags             []string
 		getSemverService  func() semverService
 		getReleaseService func() releaseService
 		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int)
@@ -26,6 +36,7 @@ func TestReleaseCreate(t *testing.T) {
 		{
 			"No argument provided",
 			[]string{},
+			[]string{},
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}}
 			},
@@ -42,6 +53,7 @@ func TestReleaseCreate(t *testing.T) {
 		{
 			"An error occurred when fetching label",
 			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
 			func() semverService {
 				return semverServiceMock{err: fmt.Errorf("failure occurred when fetching label"), methodCallCount: map[string]int{}}
 			},
@@ -59,6 +71,7 @@ func TestReleaseCreate(t *testing.T) {
 		{
 			"Failure occurred when creating release",
 			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}}
 			},
@@ -70,13 +83,17 @@ func TestReleaseCreate(t *testing.T) {
 				assert.Equal(t, "failure occurred when creating release\n", stderr.String())
 				assert.Len(t, semverServiceMethodCallCount, 1)
 				assert.Equal(t, 1, semverServiceMethodCallCount["GetFromCommit"])
-				assert.Len(t, releaseServiceMethodCallCount, 1)
+				assert.Len(t, releaseServiceMethodCallCount, 4)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetNext"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CheckGoModule"])
 				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
 			},
 		},
 		{
 			"Create release",
 			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}}
 			},
@@ -88,13 +105,17 @@ func TestReleaseCreate(t *testing.T) {
 				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
 				assert.Len(t, semverServiceMethodCallCount, 1)
 				assert.Equal(t, 1, semverServiceMethodCallCount["GetFromCommit"])
-				assert.Len(t, releaseServiceMethodCallCount, 1)
+				assert.Len(t, releaseServiceMethodCallCount, 4)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetNext"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CheckGoModule"])
 				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
 			},
 		},
 		{
 			"Skip tag creation on norelease version",
 			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
 			func() semverService {
 				return semverServiceMock{methodCallCount: map[string]int{}, version: github.NORELEASE}
 			},
@@ -109,6 +130,722 @@ func TestReleaseCreate(t *testing.T) {
 				assert.Len(t, releaseServiceMethodCallCount, 0)
 			},
 		},
+		{
+			"An error occurred when fetching the tag attached to the commit",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTagErr: fmt.Errorf("can't fetch tags"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch tags\n", stderr.String())
+				assert.Len(t, releaseServiceMethodCallCount, 1)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+			},
+		},
+		{
+			"Skip tag creation when the commit is already tagged",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, status: github.PUBLISHED, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "commit 8a5ed8235d18fb0243493b82baf5d988459d24db is already tagged with v1.2.0, skip tag creation\n", stdout.String())
+				assert.Len(t, releaseServiceMethodCallCount, 2)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetReleaseStatus"])
+			},
+		},
+		{
+			"Skip tag creation when the commit is already tagged and no release is expected",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tag-only"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "commit 8a5ed8235d18fb0243493b82baf5d988459d24db is already tagged with v1.2.0, skip tag creation\n", stdout.String())
+				assert.Len(t, releaseServiceMethodCallCount, 1)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+			},
+		},
+		{
+			"Create the missing release of a tag left on the commit by a failed run",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, status: github.NOTRELEASED, tag: github.Tag{LeadingV: true, Major: 1, Minor: 2}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "release of existing tag v1.2.0 created\n", stdout.String())
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
+				assert.Equal(t, 0, releaseServiceMethodCallCount["GetNext"])
+			},
+		},
+		{
+			"An error occurred when fetching the release of the tag on the commit",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, statusErr: fmt.Errorf("can't fetch releases"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch releases\n", stderr.String())
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Force tag creation when the commit is already tagged",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--force"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, tag: github.Tag{LeadingV: true, Major: 1, Minor: 3}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag v1.3.0 created\n", stdout.String())
+				assert.Len(t, releaseServiceMethodCallCount, 3)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"No file matches the asset pattern",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", filepath.Join(dir, "*.zip")},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "no file matches "+filepath.Join(dir, "*.zip")+"\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Asset pattern is not valid",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", "["},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "[ is not a valid glob pattern : syntax error in pattern\n", stderr.String())
+			},
+		},
+		{
+			"Failure occurred when uploading assets",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", filepath.Join(dir, "*")},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, uploadErr: fmt.Errorf("can't upload asset versem_linux_amd64"), options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't upload asset versem_linux_amd64, release 1.0.0 is left as a draft\n", stderr.String())
+				assert.Equal(t, github.ReleaseOptions{Draft: true}, options)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["UploadAssets"])
+				assert.Equal(t, 0, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Upload the assets of a draft release left by a failed run and publish it",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", asset},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, status: github.DRAFTED, assets: &assets}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "assets uploaded to tag v1.2.0\n", stdout.String())
+				assert.Equal(t, []string{asset}, assets)
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["UploadAssets"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Upload the assets of a draft release left by a failed run and keep it as a draft",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--draft", "--asset", asset},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, status: github.DRAFTED}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "assets uploaded to tag v1.2.0 with a draft release\n", stdout.String())
+				assert.Equal(t, 1, releaseServiceMethodCallCount["UploadAssets"])
+				assert.Equal(t, 0, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Skip a draft release left by a failed run without assets",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MINOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, commitTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, status: github.DRAFTED}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "commit 8a5ed8235d18fb0243493b82baf5d988459d24db is already tagged with v1.2.0, skip tag creation\n", stdout.String())
+				assert.Equal(t, 0, releaseServiceMethodCallCount["UploadAssets"])
+			},
+		},
+		{
+			"Failure occurred when publishing the release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", asset},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, publishErr: fmt.Errorf("can't publish release 1.0.0")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't publish release 1.0.0\n", stderr.String())
+				assert.Equal(t, 1, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Create release with assets",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--asset", filepath.Join(dir, "versem_*")},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options, assets: &assets}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
+				assert.Equal(t, github.ReleaseOptions{Draft: true}, options)
+				assert.Equal(t, []string{asset}, assets)
+				assert.Len(t, releaseServiceMethodCallCount, 6)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["UploadAssets"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Create a draft release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--draft"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created with a draft release\n", stdout.String())
+				assert.Equal(t, github.ReleaseOptions{Draft: true}, options)
+				assert.Equal(t, 0, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Create a draft release with assets",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--draft", "--asset", asset},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options, assets: &assets}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created with a draft release\n", stdout.String())
+				assert.Equal(t, []string{asset}, assets)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["UploadAssets"])
+				assert.Equal(t, 0, releaseServiceMethodCallCount["Publish"])
+			},
+		},
+		{
+			"Tag only mode can't be used with draft",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tag-only", "--draft"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--tag-only can't be used with --draft or --asset since no release is created\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Create an annotated tag without release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tag-only", "--annotated"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
+				assert.Equal(t, github.ReleaseOptions{TagOnly: true, Annotated: true}, options)
+			},
+		},
+		{
+			"Create a signed tag with a message",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tag-message", "{{.PullRequest.Title}}", "--tagger-name", "versem", "--tagger-email", "versem@example.com", "--sign-key", "/tmp/id_ed25519", "--sign-format", "ssh"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, pullRequest: &github.PullRequest{Number: 1, Title: "Fix a bug"}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
+				assert.Equal(t, 1, semverServiceMethodCallCount["GetPullRequestFromCommit"])
+				assert.True(t, options.Annotated)
+				assert.Equal(t, "tag-message", options.TagMessage.Name())
+				assert.Equal(t, &github.PullRequest{Number: 1, Title: "Fix a bug"}, options.PullRequest)
+				assert.Equal(t, &github.Tagger{Name: "versem", Email: "versem@example.com"}, options.Tagger)
+				assert.Equal(t, github.SSHSigner{KeyFile: "/tmp/id_ed25519"}, options.Signer)
+			},
+		},
+		{
+			"Bump files before creating the release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--bump-file", "file:VERSION", "--bump-file", "json:package.json:version", "--branch", "main"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
+				assert.Equal(t, github.ReleaseOptions{
+					FileUpdaters: []github.FileUpdater{
+						{Kind: "file", Path: "VERSION"},
+						{Kind: "json", Path: "package.json", Expression: "version"},
+					},
+					Branch: "main",
+				}, options)
+			},
+		},
+		{
+			"Skip a rerun when the bump commit of a previous run is released",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--bump-file", "file:VERSION", "--branch", "main"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, bumpCommit: "b4f0c2d5e8a1f3b6c9d2e5f8a1b4c7d0e3f6a9b2", bumpCommitTag: &github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "commit 8a5ed8235d18fb0243493b82baf5d988459d24db is already released with 1.0.0 on bump commit b4f0c2d5e8a1f3b6c9d2e5f8a1b4c7d0e3f6a9b2, skip tag creation\n", stdout.String())
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetBumpCommit"])
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"An error occurred when looking for the bump commit of a previous run",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--bump-file", "file:VERSION"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, bumpCommitErr: fmt.Errorf("can't fetch branch"), options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch branch\n", stderr.String())
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Create a release with a changelog",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--changelog"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Major: 1}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag 1.0.0 created\n", stdout.String())
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetNext"])
+				assert.Equal(t, &github.Changelog{Tag: github.Tag{Major: 1}}, options.Changelog)
+			},
+		},
+		{
+			"An error occurred when computing the next tag for the changelog",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--changelog"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, nextErr: fmt.Errorf("can't fetch tags")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch tags\n", stderr.String())
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Go module path doesn't allow a major release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}, version: github.MAJOR}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true, Major: 2}, goModuleErr: fmt.Errorf("module path github.com/antham/versem in go.mod must end with /v2 to release v2.0.0")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "module path github.com/antham/versem in go.mod must end with /v2 to release v2.0.0\n", stderr.String())
+				assert.Equal(t, 0, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"File updater is invalid",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--bump-file", "VERSION"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "VERSION is not a valid file updater, use kind:path[:expression]\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Tag message is not a valid template",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tag-message", "{{.Tag"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--tag-message is not a valid template : template: tag-message:1: unclosed action\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Tagger name is defined without email",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tagger-name", "versem"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--tagger-name and --tagger-email must be defined together\n", stderr.String())
+			},
+		},
+		{
+			"Sign key is defined without tagger",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--sign-key", "ABCDEF"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--sign-key requires --tagger-name and --tagger-email\n", stderr.String())
+			},
+		},
+		{
+			"Signature format is not supported",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--sign-key", "ABCDEF", "--sign-format", "x509", "--tagger-name", "versem", "--tagger-email", "versem@example.com"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "x509 is not a valid signature format, use gpg or ssh\n", stderr.String())
+			},
+		},
+		{
+			"Component is not valid",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "api is not a valid component, use name:prefix:glob[,glob...]\n", stderr.String())
+			},
+		},
+		{
+			"Components can't be used with changelog",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api:api/:api/**", "--changelog"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--component can't be used with --bump-file or --changelog\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"No component touched by the pull request",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api:api/:api/**"},
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{}, methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "no component touched by commit 8a5ed8235d18fb0243493b82baf5d988459d24db, skip tag creation\n", stdout.String())
+				assert.Equal(t, 1, semverServiceMethodCallCount["GetComponentsFromCommit"])
+				assert.Equal(t, 0, semverServiceMethodCallCount["GetFromCommit"])
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Create a tag for the touched component",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api:api/:api/**", "--component", "cli:cli-:cli/**"},
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{{Component: github.Component{Name: "api", Prefix: "api/", Paths: []string{"api/**"}}, Version: github.MINOR}}, methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{tag: github.Tag{Prefix: "api/", LeadingV: true, Major: 1, Minor: 4}, options: &options, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag api/v1.4.0 created\n", stdout.String())
+				assert.Equal(t, &github.Component{Name: "api", Prefix: "api/", Paths: []string{"api/**"}}, options.Component)
+				assert.Equal(t, 1, releaseServiceMethodCallCount["GetFromCommit"])
+				assert.Equal(t, 1, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Create a tag for every touched component with its own version",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api:api/:api/**", "--component", "cli:cli-:cli/**", "--component", "web:web/:web/**"},
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{
+					{Component: github.Component{Name: "api"}, Version: github.MAJOR},
+					{Component: github.Component{Name: "web"}, Version: github.NORELEASE},
+					{Component: github.Component{Name: "cli"}, Version: github.PATCH},
+				}, methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{tag: github.Tag{LeadingV: true, Patch: 1}, options: &options, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag v0.0.1 created\nlabel norelease found for component web, skip tag creation\ntag v0.0.1 created\n", stdout.String())
+				assert.Equal(t, "cli", options.Component.Name)
+				assert.Equal(t, 2, releaseServiceMethodCallCount["GetNext"])
+				assert.Equal(t, 2, releaseServiceMethodCallCount["CreateNext"])
+			},
+		},
+		{
+			"An error occurred when fetching versions of components",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--component", "api:api/:api/**"},
+			func() semverService {
+				return semverServiceMock{err: fmt.Errorf("an error occurred when parsing version of component api from pull request #1347 : no semver label found"), methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "an error occurred when parsing version of component api from pull request #1347 : no semver label found\n", stderr.String())
+				assert.Len(t, releaseServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Build metadata template is not valid",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--build-metadata", "{{.Build}}"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "build metadata  is not valid, use dot separated alphanumerics and hyphens\n", stderr.String())
+				assert.Len(t, semverServiceMethodCallCount, 0)
+			},
+		},
+		{
+			"Build metadata tag requires build metadata",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--build-metadata-tag"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--build-metadata-tag requires --build-metadata\n", stderr.String())
+			},
+		},
+		{
+			"Build metadata of a release can't be used without release",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--build-metadata", "g{{.ShortSha}}", "--tag-only"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--build-metadata names the release, use --build-metadata-tag with --tag-only\n", stderr.String())
+			},
+		},
+		{
+			"Output format is not valid",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--output", "yaml"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "yaml is not a valid output format, use text or json\n", stderr.String())
+			},
+		},
+		{
+			"Create release named with build metadata",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--build-metadata", "g{{.ShortSha}}.{{.Build}}", "--build-number", "42", "--output", "json"},
+			func() semverService {
+				return semverServiceMock{methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, lastTag: &github.Tag{LeadingV: true, Major: 1, Minor: 3}, tag: github.Tag{LeadingV: true, Major: 1, Minor: 4}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "g8a5ed82.42", options.BuildMetadata)
+				assert.False(t, options.BuildMetadataTag)
+				assert.Equal(t, `{"tag":"v1.4.0","version":"1.4.0","previousTag":"v1.3.0","commitSha":"8a5ed8235d18fb0243493b82baf5d988459d24db","buildMetadata":"g8a5ed82.42","release":"v1.4.0+g8a5ed82.42","draft":false}`+"\n", stdout.String())
+			},
+		},
+		{
+			"Create an additional tag with build metadata",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--build-metadata", "g{{.ShortSha}}", "--build-metadata-tag", "--tag-only", "--output", "json", "--component", "api:api/:api/**"},
+			func() semverService {
+				return semverServiceMock{components: []github.ComponentVersion{
+					{Component: github.Component{Name: "web"}, Version: github.NORELEASE},
+					{Component: github.Component{Name: "api"}, Version: github.MINOR},
+				}, methodCallCount: map[string]int{}}
+			},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{Prefix: "api/", LeadingV: true, Major: 1, Minor: 4}, options: &options}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, semverServiceMethodCallCount map[string]int, releaseServiceMethodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.True(t, options.BuildMetadataTag)
+				assert.Equal(t, "label norelease found for component web, skip tag creation\n", stderr.String())
+				assert.Equal(t, `{"tag":"api/v1.4.0","version":"1.4.0","component":"api","commitSha":"8a5ed8235d18fb0243493b82baf5d988459d24db","buildMetadata":"g8a5ed82","buildMetadataTag":"api/v1.4.0+g8a5ed82","draft":false}`+"\n", stdout.String())
+			},
+		},
 	}
 
 	for _, scenario := range scenarios {
@@ -130,8 +867,31 @@ func TestReleaseCreate(t *testing.T) {
 
 			w.Add(1)
 
+			options = github.ReleaseOptions{}
+			assets = nil
 			semverService := scenario.getSemverService()
 			releaseService := scenario.getReleaseService()
+			changelogService := changelogServiceMock{changelog: github.Changelog{Tag: github.Tag{Major: 1}}, methodCallCount: map[string]int{}}
+			cmd := &cobra.Command{}
+			cmd.Flags().StringArray("asset", []string{}, "")
+			cmd.Flags().Bool("draft", false, "")
+			cmd.Flags().Bool("tag-only", false, "")
+			cmd.Flags().Bool("annotated", false, "")
+			cmd.Flags().Bool("force", false, "")
+			cmd.Flags().StringArray("bump-file", []string{}, "")
+			cmd.Flags().String("branch", "", "")
+			cmd.Flags().Bool("changelog", false, "")
+			cmd.Flags().String("tag-message", "", "")
+			cmd.Flags().String("tagger-name", "", "")
+			cmd.Flags().String("tagger-email", "", "")
+			cmd.Flags().String("sign-key", "", "")
+			cmd.Flags().String("sign-format", "gpg", "")
+			cmd.Flags().StringArray("component", []string{}, "")
+			cmd.Flags().String("build-metadata", "", "")
+			cmd.Flags().String("build-number", "", "")
+			cmd.Flags().Bool("build-metadata-tag", false, "")
+			cmd.Flags().String("output", "text", "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
 
 			go func() {
 				defer func() {
@@ -142,7 +902,7 @@ func TestReleaseCreate(t *testing.T) {
 					w.Done()
 				}()
 
-				releaseCreate(msgHandler, semverService, releaseService, &cobra.Command{}, scenario.arguments)
+				releaseCreate(msgHandler, semverService, releaseService, changelogService, cmd, scenario.arguments)
 			}()
 
 			w.Wait()
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseDiscardCmd = &cobra.Command{
+	Use:   "discard [tag]",
+	Short: "Delete a draft release and its tag",
+	Run:   setupReleaseDiscardCmdFunc(releaseDiscard),
+}
+
+func init() {
+	releaseCmd.AddCommand(releaseDiscardCmd)
+}
+
+func setupReleaseDiscardCmdFunc(f func(messageHandler, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getReleaseService(), cmd, args)
+	}
+}
+
+func releaseDiscard(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("provide a semver tag as first argument")
+	}
+
+	tag, err := github.NewPrefixedTagFromString(args[0], getScheme())
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if err := releaseService.Discard(tag); err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success("release %s discarded", tag)
+}
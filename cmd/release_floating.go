@@ -0,0 +1,57 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseFloatingCmd = &cobra.Command{
+	Use:   "floating [tag]",
+	Short: "Output the floating aliases of a release like 1, 1.4, 1.4.2 and latest",
+	Run:   setupReleaseFloatingCmdFunc(releaseFloating),
+}
+
+func init() {
+	releaseFloatingCmd.Flags().Bool("move", false, "create or move the git tags of the aliases to the commit of the release")
+	releaseCmd.AddCommand(releaseFloatingCmd)
+}
+
+func setupReleaseFloatingCmdFunc(f func(messageHandler, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getReleaseService(), cmd, args)
+	}
+}
+
+func releaseFloating(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("provide a semver tag as first argument")
+	}
+
+	tag, err := github.NewPrefixedTagFromString(args[0], getScheme())
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	aliases, err := releaseService.GetFloatingTags(tag)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if move, _ := cmd.Flags().GetBool("move"); move {
+		moved, err := releaseService.MoveFloatingTags(tag, aliases)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		// aliases are the only output on stdout so they can be piped
+		for _, name := range moved {
+			msgHandler.warning("tag %s moved to %s", name, tag)
+		}
+	}
+
+	for _, alias := range aliases {
+		msgHandler.success(alias)
+	}
+}
@@ -0,0 +1,146 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReleaseFloating(t *testing.T) {
+	type scenario struct {
+		name              string
+		arguments         []string
+		flags             []string
+		getReleaseService func() releaseService
+		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"No argument provided",
+			[]string{},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "provide a semver tag as first argument\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Argument is not a semver tag",
+			[]string{"whatever"},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "whatever is not a valid semver tag\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"An error occurred when computing floating tags",
+			[]string{"v1.4.2"},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{floatingErr: fmt.Errorf("can't fetch tags"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch tags\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["GetFloatingTags"])
+			},
+		},
+		{
+			"Output floating tags",
+			[]string{"v1.4.2"},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{aliases: []string{"1", "1.4", "1.4.2", "latest"}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1\n1.4\n1.4.2\nlatest\n", stdout.String())
+				assert.Equal(t, 0, methodCallCount["MoveFloatingTags"])
+			},
+		},
+		{
+			"An error occurred when moving floating tags",
+			[]string{"v1.4.2"},
+			[]string{"--move"},
+			func() releaseService {
+				return releaseServiceMock{aliases: []string{"1.4", "1.4.2"}, moveErr: fmt.Errorf("can't move tag v1.4"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't move tag v1.4\n", stderr.String())
+				assert.Equal(t, "", stdout.String())
+			},
+		},
+		{
+			"Move floating tags",
+			[]string{"v1.4.2"},
+			[]string{"--move"},
+			func() releaseService {
+				return releaseServiceMock{aliases: []string{"1", "1.4", "1.4.2", "latest"}, moved: []string{"v1.4", "latest"}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1\n1.4\n1.4.2\nlatest\n", stdout.String())
+				assert.Equal(t, "tag v1.4 moved to v1.4.2\ntag latest moved to v1.4.2\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["MoveFloatingTags"])
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			releaseService := scenario.getReleaseService()
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("move", false, "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				releaseFloating(msgHandler, releaseService, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, releaseService.(releaseServiceMock).methodCallCount)
+		})
+	}
+}
@@ -0,0 +1,70 @@
+package cmd
+
+import (
+	"regexp"
+
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseGraduateCmd = &cobra.Command{
+	Use:   "graduate [commitSha]",
+	Short: "Release 1.0.0 on a repository still releasing 0.x versions",
+	Run:   setupReleaseGraduateCmdFunc(releaseGraduate),
+}
+
+func init() {
+	releaseGraduateCmd.Flags().Bool("tag-only", false, "create the git tag without any github release")
+	releaseCmd.AddCommand(releaseGraduateCmd)
+}
+
+func setupReleaseGraduateCmdFunc(f func(messageHandler, github.Scheme, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		scheme := getScheme()
+
+		if s, ok := scheme.(github.SemverScheme); ok {
+			s.ZeroMajorAsMinor = false
+			s.InitialVersion = nil
+			scheme = s
+		}
+
+		f(msgHandler, scheme, github.NewReleaseService(getCredentials()).WithScheme(scheme), cmd, args)
+	}
+}
+
+// releaseGraduate creates the 1.0.0 tag with a semver scheme bumping
+// the major version, whatever the 0.x semantics or initial version configured
+func releaseGraduate(msgHandler messageHandler, scheme github.Scheme, releaseService releaseService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 || !regexp.MustCompile("[0-9a-f]{40}").MatchString(args[0]) {
+		msgHandler.errorFatalStr("provide a full commit sha as first argument")
+	}
+
+	if _, ok := scheme.(github.SemverScheme); !ok {
+		msgHandler.errorFatalStr("only semver tags can graduate to 1.0.0")
+	}
+
+	lastTag, nextTag, err := releaseService.GetNext(github.MAJOR, nil)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if lastTag != nil && lastTag.Major > 0 {
+		msgHandler.errorFatalStr("latest tag %s is already a stable version", lastTag)
+	}
+
+	if nextTag.Major != 1 || nextTag.Minor != 0 || nextTag.Patch != 0 || nextTag.PreRelease != "" {
+		msgHandler.errorFatalStr("next tag %s is not 1.0.0, graduation is refused", nextTag)
+	}
+
+	options := github.ReleaseOptions{}
+	options.TagOnly, _ = cmd.Flags().GetBool("tag-only")
+
+	tag, err := releaseService.CreateNext(github.MAJOR, args[0], options)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success("tag %s created", tag)
+}
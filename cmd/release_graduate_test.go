@@ -0,0 +1,160 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/antham/versem/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReleaseGraduate(t *testing.T) {
+	var options github.ReleaseOptions
+
+	type scenario struct {
+		name              string
+		arguments         []string
+		scheme            github.Scheme
+		getReleaseService func() releaseService
+		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"No argument provided",
+			[]string{},
+			github.SemverScheme{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "provide a full commit sha as first argument\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Scheme is not semver",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.CalVerScheme{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "only semver tags can graduate to 1.0.0\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Failure occurred when fetching the latest tag",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.SemverScheme{ZeroMajorAsMinor: true},
+			func() releaseService {
+				return releaseServiceMock{nextErr: fmt.Errorf("can't fetch latest tag"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch latest tag\n", stderr.String())
+			},
+		},
+		{
+			"Latest tag is already stable",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.SemverScheme{ZeroMajorAsMinor: true},
+			func() releaseService {
+				return releaseServiceMock{lastTag: &github.Tag{LeadingV: true, Major: 1, Minor: 2}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "latest tag v1.2.0 is already a stable version\n", stderr.String())
+				assert.Equal(t, 0, methodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Next tag is not 1.0.0",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.SemverScheme{},
+			func() releaseService {
+				return releaseServiceMock{tag: github.Tag{Minor: 1}, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "next tag 0.1.0 is not 1.0.0, graduation is refused\n", stderr.String())
+				assert.Equal(t, 0, methodCallCount["CreateNext"])
+			},
+		},
+		{
+			"Failure occurred when creating the tag",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.SemverScheme{ZeroMajorAsMinor: true},
+			func() releaseService {
+				return releaseServiceMock{lastTag: &github.Tag{LeadingV: true, Minor: 9}, tag: github.Tag{LeadingV: true, Major: 1}, err: fmt.Errorf("can't create tag"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't create tag\n", stderr.String())
+			},
+		},
+		{
+			"Release 1.0.0",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			github.SemverScheme{ZeroMajorAsMinor: true},
+			func() releaseService {
+				return releaseServiceMock{lastTag: &github.Tag{LeadingV: true, Minor: 9}, tag: github.Tag{LeadingV: true, Major: 1}, options: &options, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag v1.0.0 created\n", stdout.String())
+				assert.True(t, options.TagOnly)
+				assert.Equal(t, 1, methodCallCount["GetNext"])
+				assert.Equal(t, 1, methodCallCount["CreateNext"])
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			releaseService := scenario.getReleaseService()
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("tag-only", true, "")
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				releaseGraduate(msgHandler, scenario.scheme, releaseService, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, releaseService.(releaseServiceMock).methodCallCount)
+		})
+	}
+}
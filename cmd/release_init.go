@@ -0,0 +1,48 @@
+package cmd
+
+import (
+	"regexp"
+
+	"github.com/spf13/cobra"
+)
+
+var releaseInitCmd = &cobra.Command{
+	Use:   "init [commitSha]",
+	Short: "Create the first tag of a repository with the initial version",
+	Run:   setupReleaseInitCmdFunc(releaseInit),
+}
+
+func init() {
+	releaseInitCmd.Flags().Bool("annotated", false, "create an annotated tag instead of a lightweight one")
+	releaseInitCmd.Flags().String("tag-message", "", "go template of the annotated tag message, it receives .Tag")
+	releaseInitCmd.Flags().String("tagger-name", "", "name of the annotated tag author")
+	releaseInitCmd.Flags().String("tagger-email", "", "email of the annotated tag author")
+	releaseInitCmd.Flags().String("sign-key", "", "sign the annotated tag with this gpg key id or ssh private key file")
+	releaseInitCmd.Flags().String("sign-format", "gpg", "format of the tag signature : gpg or ssh")
+	releaseCmd.AddCommand(releaseInitCmd)
+}
+
+func setupReleaseInitCmdFunc(f func(messageHandler, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getReleaseService(), cmd, args)
+	}
+}
+
+func releaseInit(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 || !regexp.MustCompile("[0-9a-f]{40}").MatchString(args[0]) {
+		msgHandler.errorFatalStr("provide a full commit sha as first argument")
+	}
+
+	options, err := getTagOptions(cmd)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	tag, err := releaseService.Init(args[0], options)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success("tag %s created", tag)
+}
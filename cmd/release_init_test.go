@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/antham/versem/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReleaseInit(t *testing.T) {
+	var options github.ReleaseOptions
+
+	type scenario struct {
+		name              string
+		arguments         []string
+		flags             []string
+		getReleaseService func() releaseService
+		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"No argument provided",
+			[]string{},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "provide a full commit sha as first argument\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Tagger is incomplete",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--tagger-name", "versem"},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "--tagger-name and --tagger-email must be defined together\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Repository already has a tag",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{err: fmt.Errorf("tag v0.3.0 already exists, nothing to initialize"), methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "tag v0.3.0 already exists, nothing to initialize\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["Init"])
+			},
+		},
+		{
+			"Create the first tag",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--annotated"},
+			func() releaseService {
+				return releaseServiceMock{tag: github.Tag{LeadingV: true, Minor: 1}, options: &options, methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "tag v0.1.0 created\n", stdout.String())
+				assert.True(t, options.Annotated)
+				assert.Equal(t, 1, methodCallCount["Init"])
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			options = github.ReleaseOptions{}
+			releaseService := scenario.getReleaseService()
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("annotated", false, "")
+			cmd.Flags().String("tag-message", "", "")
+			cmd.Flags().String("tagger-name", "", "")
+			cmd.Flags().String("tagger-email", "", "")
+			cmd.Flags().String("sign-key", "", "")
+			cmd.Flags().String("sign-format", "gpg", "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				releaseInit(msgHandler, releaseService, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, releaseService.(releaseServiceMock).methodCallCount)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var releasePublishCmd = &cobra.Command{
+	Use:   "publish [tag]",
+	Short: "Publish a draft release",
+	Run:   setupReleasePublishCmdFunc(releasePublish),
+}
+
+func init() {
+	releaseCmd.AddCommand(releasePublishCmd)
+}
+
+func setupReleasePublishCmdFunc(f func(messageHandler, releaseService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getReleaseService(), cmd, args)
+	}
+}
+
+func releasePublish(msgHandler messageHandler, releaseService releaseService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("provide a semver tag as first argument")
+	}
+
+	tag, err := github.NewPrefixedTagFromString(args[0], getScheme())
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if err := releaseService.Publish(tag); err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success("release %s published", tag)
+}
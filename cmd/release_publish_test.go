@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestReleasePublish(t *testing.T) {
+	type scenario struct {
+		name              string
+		arguments         []string
+		getReleaseService func() releaseService
+		test              func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"No argument provided",
+			[]string{},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "provide a semver tag as first argument\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Argument is not a semver tag",
+			[]string{"whatever"},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "whatever is not a valid semver tag\n", stderr.String())
+				assert.Len(t, methodCallCount, 0)
+			},
+		},
+		{
+			"Failure occurred when publishing the release",
+			[]string{"v1.1.0"},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}, publishErr: fmt.Errorf("no release found for tag v1.1.0")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "no release found for tag v1.1.0\n", stderr.String())
+				assert.Len(t, methodCallCount, 1)
+				assert.Equal(t, 1, methodCallCount["Publish"])
+			},
+		},
+		{
+			"Publish the release",
+			[]string{"v1.1.0"},
+			func() releaseService {
+				return releaseServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "release v1.1.0 published\n", stdout.String())
+				assert.Len(t, methodCallCount, 1)
+				assert.Equal(t, 1, methodCallCount["Publish"])
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			releaseService := scenario.getReleaseService()
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				releasePublish(msgHandler, releaseService, &cobra.Command{}, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, releaseService.(releaseServiceMock).methodCallCount)
+		})
+	}
+
+}
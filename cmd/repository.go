@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+var repositoryRegexp = regexp.MustCompile(`^([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+)$`)
+
+// remoteURLRegexp matches https and ssh remote urls,
+// like https://github.com/antham/versem.git or git@github.com:antham/versem.git
+var remoteURLRegexp = regexp.MustCompile(`^(?:(?:https?|ssh|git)://(?:[^@/]+@)?[^/]+/|[^@/]+@[^:/]+:)([A-Za-z0-9_.-]+)/([A-Za-z0-9_.-]+?)(?:\.git)?/?$`)
+
+// getRepository returns the owner and the name of the repository, from --repo,
+// then GITHUB_OWNER and GITHUB_REPOSITORY which can be given as owner/name like
+// in github actions, then the origin remote of the git repository of a directory
+func getRepository(dir string) (string, string, error) {
+	if repository := viper.GetString(repoKey); repository != "" {
+		return parseRepository(repository)
+	}
+
+	owner, repository := viper.GetString(ownerKey), viper.GetString(repositoryKey)
+
+	if strings.Contains(repository, "/") {
+		return parseRepository(repository)
+	}
+
+	if owner != "" && repository != "" {
+		return owner, repository, nil
+	}
+
+	owner, repository, err := getOriginRepository(dir)
+	if err != nil {
+		return "", "", fmt.Errorf("can't find the repository, use --repo owner/name, %s and %s or a git origin remote : %s", githubOwner, githubRepository, err)
+	}
+
+	return owner, repository, nil
+}
+
+// parseRepository splits a repository defined as owner/name
+func parseRepository(repository string) (string, string, error) {
+	matches := repositoryRegexp.FindStringSubmatch(repository)
+	if matches == nil {
+		return "", "", fmt.Errorf("%s is not a valid repository, use owner/name", repository)
+	}
+
+	return matches[1], matches[2], nil
+}
+
+// parseRemoteURL extracts the owner and the name of a repository from a git remote url
+func parseRemoteURL(url string) (string, string, error) {
+	matches := remoteURLRegexp.FindStringSubmatch(strings.TrimSpace(url))
+	if matches == nil {
+		return "", "", fmt.Errorf("%s is not a valid remote url", strings.TrimSpace(url))
+	}
+
+	return matches[1], matches[2], nil
+}
+
+func getOriginRepository(dir string) (string, string, error) {
+	output, err := exec.Command("git", "-C", dir, "remote", "get-url", "origin").Output()
+	if err != nil {
+		return "", "", fmt.Errorf("can't read origin remote : %s", err)
+	}
+
+	return parseRemoteURL(string(output))
+}
@@ -1,6 +1,8 @@
 package cmd
 
 import (
+	"os"
+
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
 )
@@ -9,10 +11,51 @@ import (
 const githubToken = "GITHUB_TOKEN"
 const githubOwner = "GITHUB_OWNER"
 const githubRepository = "GITHUB_REPOSITORY"
+const githubWebhookSecret = "GITHUB_WEBHOOK_SECRET"
+const versemScheme = "VERSEM_SCHEME"
+const versemZeroMajorAsMinor = "VERSEM_ZERO_MAJOR_AS_MINOR"
+const versemInitialVersion = "VERSEM_INITIAL_VERSION"
+const versemLeadingV = "VERSEM_LEADING_V"
+const versemTagTemplate = "VERSEM_TAG_TEMPLATE"
+
+// configuration keys, they can be defined in the configuration
+// file and are overridden by environment variables and flags
+const ownerKey = "github.owner"
+const repositoryKey = "github.repository"
+const tokenKey = "github.token"
+const webhookSecretKey = "github.webhook-secret"
+const schemeKey = "scheme"
+const zeroMajorAsMinorKey = "zero-major-as-minor"
+const initialVersionKey = "initial-version"
+const leadingVKey = "leading-v"
+const tagTemplateKey = "tag-template"
+const repoKey = "repo"
+
+// offlineAnnotation marks commands which don't call github
+// and don't require github environment variables
+const offlineAnnotation = "offline"
+
+// anyRepositoryAnnotation marks commands which get the repository
+// from github events, they only require the token
+const anyRepositoryAnnotation = "any-repository"
+
+// configEnvs are the environment variables of configuration keys
+var configEnvs = map[string]string{
+	ownerKey:            githubOwner,
+	repositoryKey:       githubRepository,
+	tokenKey:            githubToken,
+	webhookSecretKey:    githubWebhookSecret,
+	schemeKey:           versemScheme,
+	zeroMajorAsMinorKey: versemZeroMajorAsMinor,
+	initialVersionKey:   versemInitialVersion,
+	leadingVKey:         versemLeadingV,
+	tagTemplateKey:      versemTagTemplate,
+}
 
 var rootCmd = &cobra.Command{
-	Use:   "versem",
-	Short: "Semver manager",
+	Use:              "versem",
+	Short:            "Semver manager",
+	PersistentPreRun: setupCommand(newMessageHandler()),
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -24,20 +67,94 @@ func Execute() {
 }
 
 func init() {
+	rootCmd.PersistentFlags().String("config", "", "configuration file, "+configName+" with a yaml, toml or json extension is loaded from the root of the repository when empty")
+	rootCmd.PersistentFlags().String("repo", "", "repository as owner/name, when empty it's read from "+githubOwner+" and "+githubRepository+", which can be defined as owner/name, or from the git origin remote")
+	rootCmd.PersistentFlags().String("scheme", "semver", "versioning scheme of tags : semver or calver:format, for instance calver:YYYY.0M.MICRO, can be set with "+versemScheme)
+	rootCmd.PersistentFlags().Bool("zero-major-as-minor", false, "bump the minor version of 0.x semver tags for major changes, use release graduate to release 1.0.0, can be set with "+versemZeroMajorAsMinor)
+	rootCmd.PersistentFlags().String("initial-version", "", "first semver tag of a repository, like 0.1.0 or v1.0.0, it's computed from v0.0.0 when empty, can be set with "+versemInitialVersion)
+	rootCmd.PersistentFlags().String("leading-v", "", "add a v before versions when true, remove it when false, it's inferred from the latest tag when empty, can be set with "+versemLeadingV)
+	rootCmd.PersistentFlags().String("tag-template", "", "name of tags where {version} is replaced with the version, like release-{version}, can be set with "+versemTagTemplate)
+
+	for key, env := range configEnvs {
+		if err := viper.BindEnv(key, env); err != nil {
+			newMessageHandler().errorFatal(err)
+		}
+	}
+
+	for _, key := range []string{
+		repoKey,
+		schemeKey,
+		zeroMajorAsMinorKey,
+		initialVersionKey,
+		leadingVKey,
+		tagTemplateKey,
+	} {
+		if err := viper.BindPFlag(key, rootCmd.PersistentFlags().Lookup(key)); err != nil {
+			newMessageHandler().errorFatal(err)
+		}
+	}
+
 	cobra.OnInitialize(initConfig(newMessageHandler()))
 }
 
 func initConfig(msgHandler messageHandler) func() {
 	return func() {
-		viper.AutomaticEnv()
-		for _, key := range []string{
-			githubOwner,
-			githubRepository,
-			githubToken,
-		} {
-			if !viper.IsSet(key) {
-				msgHandler.errorFatalStr("missing environment variable : %s", key)
+		dir, err := os.Getwd()
+		if err != nil {
+			msgHandler.errorFatalStr("can't get working directory : %s", err)
+		}
+
+		file, _ := rootCmd.PersistentFlags().GetString("config")
+		if err := loadConfig(file, findRepositoryRoot(dir)); err != nil {
+			msgHandler.errorFatal(err)
+		}
+	}
+}
+
+// setupCommand fills the flags of the command from the configuration
+// file, checks the settings, the scheme and credentials, offline commands
+// skip checks so config validate can report wrong settings
+func setupCommand(msgHandler messageHandler) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if err := applyCommandConfig(cmd); err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		if _, ok := cmd.Annotations[offlineAnnotation]; !ok {
+			if err := checkConfigKeys(viper.ConfigFileUsed(), getConfigSchema(cmd.Root())); err != nil {
+				msgHandler.errorFatal(err)
+			}
+
+			if _, err := newScheme(); err != nil {
+				msgHandler.errorFatal(err)
 			}
 		}
+
+		checkCredentials(msgHandler)(cmd, args)
+	}
+}
+
+// checkCredentials ensures the repository and the github token are
+// defined, offline commands skip it and serve only requires the token
+func checkCredentials(msgHandler messageHandler) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		if _, ok := cmd.Annotations[offlineAnnotation]; ok {
+			return
+		}
+
+		if _, ok := cmd.Annotations[anyRepositoryAnnotation]; !ok {
+			dir, err := os.Getwd()
+			if err != nil {
+				msgHandler.errorFatalStr("can't get working directory : %s", err)
+			}
+
+			if _, _, err := getRepository(dir); err != nil {
+				msgHandler.errorFatal(err)
+			}
+		}
+
+		if !viper.IsSet(tokenKey) {
+			msgHandler.errorFatalStr("missing environment variable : %s", githubToken)
+		}
 	}
 }
@@ -0,0 +1,98 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckCredentials(t *testing.T) {
+	scenarios := []struct {
+		name string
+		env  map[string]string
+		cmd  *cobra.Command
+		test func(exitCode int, stderr bytes.Buffer)
+	}{
+		{
+			"Offline command doesn't require environment variables",
+			map[string]string{},
+			&cobra.Command{Annotations: map[string]string{offlineAnnotation: ""}},
+			func(exitCode int, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+			},
+		},
+		{
+			"Command handling any repository only requires the token",
+			map[string]string{"GITHUB_TOKEN": "token"},
+			&cobra.Command{Annotations: map[string]string{anyRepositoryAnnotation: ""}},
+			func(exitCode int, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+			},
+		},
+		{
+			"Environment variable is missing",
+			map[string]string{"GITHUB_OWNER": "antham", "GITHUB_REPOSITORY": "versem"},
+			&cobra.Command{},
+			func(exitCode int, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "missing environment variable : GITHUB_TOKEN\n", stderr.String())
+			},
+		},
+		{
+			"Environment variables are defined",
+			map[string]string{"GITHUB_OWNER": "antham", "GITHUB_REPOSITORY": "versem", "GITHUB_TOKEN": "token"},
+			&cobra.Command{},
+			func(exitCode int, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			for key, value := range scenario.env {
+				os.Setenv(key, value)
+			}
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				checkCredentials(msgHandler)(scenario.cmd, []string{})
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stderr)
+
+			for key := range scenario.env {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
@@ -0,0 +1,28 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+)
+
+// semverCmd represents the semver command
+var semverCmd = &cobra.Command{
+	Use:         "semver",
+	Short:       "Compute semver versions locally, no github access is needed",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run: func(cmd *cobra.Command, args []string) {
+		fmt.Println(cmd.Help())
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(semverCmd)
+}
+
+func setupSemverCmdFunc(f func(messageHandler, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, cmd, args)
+	}
+}
@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var semverBumpCmd = &cobra.Command{
+	Use:         "bump [major|minor|patch|prerelease] [version]",
+	Short:       "Bump a semver version",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupSemverCmdFunc(semverBump),
+}
+
+func init() {
+	semverBumpCmd.Flags().String("preid", "", "identifier of the pre-release, like rc or beta")
+	semverCmd.AddCommand(semverBumpCmd)
+}
+
+func semverBump(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		msgHandler.errorFatalStr("provide major, minor, patch or prerelease and a semver version as arguments")
+	}
+
+	tag, err := github.NewTagFromString(args[1])
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	preid, _ := cmd.Flags().GetString("preid")
+	if preid != "" && !github.PreReleaseIdentifierRegexp.MatchString(preid) {
+		msgHandler.errorFatalStr("%s is not a valid pre-release identifier", preid)
+	}
+
+	if preid != "" && args[0] != "prerelease" {
+		msgHandler.errorFatalStr("--preid can only be used with prerelease")
+	}
+
+	switch args[0] {
+	case "major":
+		tag = tag.Bump(github.MAJOR)
+	case "minor":
+		tag = tag.Bump(github.MINOR)
+	case "patch":
+		tag = tag.Bump(github.PATCH)
+	case "prerelease":
+		tag = tag.BumpPreRelease(preid)
+	default:
+		msgHandler.errorFatalStr("%s is not a valid bump, use major, minor, patch or prerelease", args[0])
+	}
+
+	msgHandler.success(tag.String())
+}
@@ -0,0 +1,194 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSemverBump(t *testing.T) {
+	type scenario struct {
+		name      string
+		arguments []string
+		flags     []string
+		test      func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}
+
+	scenarios := []scenario{
+		{
+			"No arguments provided",
+			[]string{},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "provide major, minor, patch or prerelease and a semver version as arguments\n", stderr.String())
+			},
+		},
+		{
+			"Version is not valid",
+			[]string{"minor", "1.2"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "1.2 is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"Bump is not valid",
+			[]string{"build", "1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "build is not a valid bump, use major, minor, patch or prerelease\n", stderr.String())
+			},
+		},
+		{
+			"Pre-release identifier is not valid",
+			[]string{"prerelease", "1.2.3"},
+			[]string{"--preid", "rc.1"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "rc.1 is not a valid pre-release identifier\n", stderr.String())
+			},
+		},
+		{
+			"Pre-release identifier without prerelease bump",
+			[]string{"minor", "1.2.3"},
+			[]string{"--preid", "rc"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "--preid can only be used with prerelease\n", stderr.String())
+			},
+		},
+		{
+			"Bump major version",
+			[]string{"major", "v1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "v2.0.0\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Bump minor version",
+			[]string{"minor", "1.2.3-rc.1"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.3.0\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Bump patch version",
+			[]string{"patch", "1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.2.4\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Bump patch version of a pre-release",
+			[]string{"patch", "1.2.3-rc.1"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.2.3\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Bump pre-release version",
+			[]string{"prerelease", "1.2.3"},
+			[]string{"--preid", "rc"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.2.4-rc.0\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Pre-release identifier with leading zeros is not valid",
+			[]string{"prerelease", "1.2.3"},
+			[]string{"--preid", "01"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "01 is not a valid pre-release identifier\n", stderr.String())
+			},
+		},
+		{
+			"Bump pre-release version with an hyphenated identifier",
+			[]string{"prerelease", "1.2.3"},
+			[]string{"--preid", "rc-1"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.2.4-rc-1.0\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Bump pre-release number",
+			[]string{"prerelease", "1.2.4-rc.0"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1.2.4-rc.1\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("preid", "", "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				semverBump(msgHandler, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr)
+		})
+	}
+}
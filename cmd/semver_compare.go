@@ -0,0 +1,37 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var semverCompareCmd = &cobra.Command{
+	Use:         "compare [version] [version]",
+	Short:       "Compare two semver versions, output -1, 0 or 1 when the first one is lower, equal or higher",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupSemverCmdFunc(semverCompare),
+}
+
+func init() {
+	semverCmd.AddCommand(semverCompareCmd)
+}
+
+func semverCompare(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		msgHandler.errorFatalStr("provide two semver versions as arguments")
+	}
+
+	tags := []github.Tag{}
+
+	for _, arg := range args {
+		tag, err := github.NewTagFromString(arg)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	msgHandler.success("%d", tags[0].Compare(tags[1]))
+}
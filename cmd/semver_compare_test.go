@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSemverCompare(t *testing.T) {
+	type scenario struct {
+		name      string
+		arguments []string
+		flags     []string
+		test      func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}
+
+	scenarios := []scenario{
+		{
+			"No arguments provided",
+			[]string{"1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "provide two semver versions as arguments\n", stderr.String())
+			},
+		},
+		{
+			"Version is not valid",
+			[]string{"1.2.3", "whatever"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "whatever is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"First version is lower",
+			[]string{"1.2.3", "v1.10.0"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "-1\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"Versions are equal",
+			[]string{"1.2.3+g8a5ed82", "v1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "0\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+		{
+			"First version is higher",
+			[]string{"1.2.3", "1.2.3-rc.1"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "1\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			cmd := &cobra.Command{}
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				semverCompare(msgHandler, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr)
+		})
+	}
+}
@@ -0,0 +1,40 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var semverSatisfiesCmd = &cobra.Command{
+	Use:         "satisfies [version] [constraint]",
+	Short:       "Check a semver version is in a range like ^1.2, ~1.2.3, >=1.0.0 <2.0.0 or 1.x, it exits with an error otherwise",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupSemverCmdFunc(semverSatisfies),
+}
+
+func init() {
+	semverCmd.AddCommand(semverSatisfiesCmd)
+}
+
+func semverSatisfies(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 2 {
+		msgHandler.errorFatalStr("provide a semver version and a constraint as arguments")
+	}
+
+	tag, err := github.NewTagFromString(args[0])
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	constraint, err := github.NewConstraintFromString(args[1])
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	if !tag.Satisfies(constraint) {
+		msgHandler.errorFatalStr("%s doesn't satisfy %s", tag, constraint)
+	}
+
+	msgHandler.success("%s satisfies %s", tag, constraint)
+}
@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSemverSatisfies(t *testing.T) {
+	type scenario struct {
+		name      string
+		arguments []string
+		flags     []string
+		test      func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}
+
+	scenarios := []scenario{
+		{
+			"No arguments provided",
+			[]string{"1.2.3"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "provide a semver version and a constraint as arguments\n", stderr.String())
+			},
+		},
+		{
+			"Version is not valid",
+			[]string{"1.2", "^1.2"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "1.2 is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"Constraint is not valid",
+			[]string{"1.2.3", ">=1.a"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, ">=1.a is not a valid constraint : 1.a is not a valid version\n", stderr.String())
+			},
+		},
+		{
+			"Version doesn't satisfy the constraint",
+			[]string{"2.0.0-rc.1", ">=1.0.0 <2.0.0"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "2.0.0-rc.1 doesn't satisfy >=1.0.0 <2.0.0\n", stderr.String())
+			},
+		},
+		{
+			"Version satisfies the constraint",
+			[]string{"v1.4.2", "^1.2"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "v1.4.2 satisfies ^1.2\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			cmd := &cobra.Command{}
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				semverSatisfies(msgHandler, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr)
+		})
+	}
+}
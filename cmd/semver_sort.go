@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"bufio"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var semverSortCmd = &cobra.Command{
+	Use:         "sort",
+	Short:       "Sort the semver versions read from stdin, one per line",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupSemverSortCmdFunc(semverSort),
+}
+
+func init() {
+	semverSortCmd.Flags().Bool("reverse", false, "sort from the highest to the lowest version")
+	semverCmd.AddCommand(semverSortCmd)
+}
+
+func setupSemverSortCmdFunc(f func(messageHandler, io.Reader, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, os.Stdin, cmd, args)
+	}
+}
+
+func semverSort(msgHandler messageHandler, stdin io.Reader, cmd *cobra.Command, args []string) {
+	tags := []github.Tag{}
+	scanner := bufio.NewScanner(stdin)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		tag, err := github.NewTagFromString(line)
+		if err != nil {
+			msgHandler.errorFatal(err)
+		}
+
+		tags = append(tags, tag)
+	}
+
+	if err := scanner.Err(); err != nil {
+		msgHandler.errorFatalStr("can't read versions : %s", err)
+	}
+
+	github.SortTags(tags)
+
+	if reverse, _ := cmd.Flags().GetBool("reverse"); reverse {
+		for i, j := 0, len(tags)-1; i < j; i, j = i+1, j-1 {
+			tags[i], tags[j] = tags[j], tags[i]
+		}
+	}
+
+	for _, tag := range tags {
+		msgHandler.success(tag.String())
+	}
+}
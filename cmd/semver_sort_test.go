@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSemverSort(t *testing.T) {
+	type scenario struct {
+		name  string
+		stdin string
+		flags []string
+		test  func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}
+
+	scenarios := []scenario{
+		{
+			"Version is not valid",
+			"1.2.3\nwhatever\n",
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "whatever is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"Sort versions",
+			"v1.10.0\n1.2.3\n\n  1.10.0-rc.1\n0.9.0\n",
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "0.9.0\n1.2.3\n1.10.0-rc.1\nv1.10.0\n", stdout.String())
+			},
+		},
+		{
+			"Sort versions from the highest",
+			"1.2.3\nv1.10.0\n0.9.0",
+			[]string{"--reverse"},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "v1.10.0\n1.2.3\n0.9.0\n", stdout.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			cmd := &cobra.Command{}
+			cmd.Flags().Bool("reverse", false, "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				semverSort(msgHandler, strings.NewReader(scenario.stdin), cmd, []string{})
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr)
+		})
+	}
+}
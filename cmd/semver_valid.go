@@ -0,0 +1,31 @@
+package cmd
+
+import (
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var semverValidCmd = &cobra.Command{
+	Use:         "valid [version]",
+	Short:       "Check a version follows semver, it exits with an error otherwise",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupSemverCmdFunc(semverValid),
+}
+
+func init() {
+	semverCmd.AddCommand(semverValidCmd)
+}
+
+func semverValid(msgHandler messageHandler, cmd *cobra.Command, args []string) {
+	if len(args) != 1 {
+		msgHandler.errorFatalStr("provide a semver version as first argument")
+	}
+
+	tag, err := github.NewTagFromString(args[0])
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success(tag.String())
+}
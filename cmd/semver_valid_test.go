@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"bytes"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestSemverValid(t *testing.T) {
+	type scenario struct {
+		name      string
+		arguments []string
+		flags     []string
+		test      func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer)
+	}
+
+	scenarios := []scenario{
+		{
+			"No arguments provided",
+			[]string{},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "provide a semver version as first argument\n", stderr.String())
+			},
+		},
+		{
+			"Version is not valid",
+			[]string{"1.2"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "1.2 is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"Version with leading zeros is not valid",
+			[]string{"01.02.03"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "", stdout.String())
+				assert.Equal(t, "01.02.03 is not a valid semver tag\n", stderr.String())
+			},
+		},
+		{
+			"Version is valid",
+			[]string{"v1.2.3-rc.1+g8a5ed82"},
+			[]string{},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "v1.2.3-rc.1+g8a5ed82\n", stdout.String())
+				assert.Equal(t, "", stderr.String())
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			cmd := &cobra.Command{}
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				semverValid(msgHandler, cmd, scenario.arguments)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr)
+		})
+	}
+}
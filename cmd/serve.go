@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/antham/versem/github"
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+)
+
+var serveCmd = &cobra.Command{
+	Use:         "serve",
+	Short:       "Start a webhook server handling pull request events",
+	Annotations: map[string]string{anyRepositoryAnnotation: ""},
+	Run:         setupServeCmdFunc(serve),
+}
+
+func init() {
+	serveCmd.Flags().String("addr", ":8080", "address the webhook server listens on")
+	serveCmd.Flags().String("branch", "", "branch whose merged pull requests are released, default branch of the repository when empty")
+	rootCmd.AddCommand(serveCmd)
+}
+
+func setupServeCmdFunc(f func(messageHandler, func(string, http.Handler) error, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, http.ListenAndServe, cmd, args)
+	}
+}
+
+func serve(msgHandler messageHandler, listen func(string, http.Handler) error, cmd *cobra.Command, args []string) {
+	secret := viper.GetString(webhookSecretKey)
+	if secret == "" {
+		msgHandler.errorFatalStr("missing environment variable : %s", githubWebhookSecret)
+	}
+
+	addr, _ := cmd.Flags().GetString("addr")
+	branch, _ := cmd.Flags().GetString("branch")
+
+	msgHandler.success("webhook server listening on %s", addr)
+
+	if err := listen(addr, github.NewWebhookHandler(secret, viper.GetString(tokenKey)).WithScheme(getScheme()).WithBranch(branch)); err != nil {
+		msgHandler.errorFatal(err)
+	}
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"os"
+	"sync"
+	"testing"
+
+	"github.com/antham/versem/github"
+	"github.com/spf13/cobra"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestServe(t *testing.T) {
+	type scenario struct {
+		name      string
+		setup     func()
+		getListen func(addr *string) func(string, http.Handler) error
+		test      func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, addr string)
+	}
+
+	scenarios := []scenario{
+		{
+			"Webhook secret is not defined",
+			func() {
+				os.Unsetenv("GITHUB_WEBHOOK_SECRET")
+			},
+			func(addr *string) func(string, http.Handler) error {
+				return func(a string, h http.Handler) error {
+					*addr = a
+					return nil
+				}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, addr string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "missing environment variable : GITHUB_WEBHOOK_SECRET\n", stderr.String())
+				assert.Empty(t, addr)
+			},
+		},
+		{
+			"Server fails to start",
+			func() {
+				os.Setenv("GITHUB_WEBHOOK_SECRET", "secret")
+			},
+			func(addr *string) func(string, http.Handler) error {
+				return func(a string, h http.Handler) error {
+					*addr = a
+					return fmt.Errorf("address already in use")
+				}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, addr string) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "webhook server listening on :8080\n", stdout.String())
+				assert.Equal(t, "address already in use\n", stderr.String())
+				assert.Equal(t, ":8080", addr)
+			},
+		},
+		{
+			"Start the server",
+			func() {
+				os.Setenv("GITHUB_WEBHOOK_SECRET", "secret")
+			},
+			func(addr *string) func(string, http.Handler) error {
+				return func(a string, h http.Handler) error {
+					*addr = a
+					assert.IsType(t, github.WebhookHandler{}, h)
+					return nil
+				}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, addr string) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "webhook server listening on :8080\n", stdout.String())
+				assert.Equal(t, ":8080", addr)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.setup()
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var addr string
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			cmd := &cobra.Command{}
+			cmd.Flags().String("addr", ":8080", "")
+			cmd.Flags().String("branch", "", "")
+
+			w.Add(1)
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				serve(msgHandler, scenario.getListen(&addr), cmd, []string{})
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, addr)
+		})
+	}
+
+	os.Unsetenv("GITHUB_WEBHOOK_SECRET")
+}
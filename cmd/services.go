@@ -1,6 +1,10 @@
 package cmd
 
 import (
+	"fmt"
+	"os"
+	"strconv"
+
 	"github.com/antham/versem/github"
 	"github.com/spf13/viper"
 )
@@ -8,34 +12,108 @@ import (
 type semverService interface {
 	GetFromPullRequest(int) (github.Version, error)
 	GetFromCommit(string) (github.Version, error)
+	GetPullRequestFromCommit(string) (*github.PullRequest, error)
+	GetComponentsFromPullRequest(int, []github.Component) ([]github.ComponentVersion, error)
+	GetComponentsFromCommit(string, []github.Component) ([]github.ComponentVersion, error)
 	CreateList() error
 }
 
 type releaseService interface {
-	CreateNext(github.Version, string) (github.Tag, error)
+	GetNext(github.Version, *github.Component) (*github.Tag, github.Tag, error)
+	GetFromCommit(string, *github.Component) (*github.Tag, error)
+	GetBumpCommit(string, string) (string, error)
+	GetReleaseStatus(github.Tag) (github.ReleaseStatus, error)
+	CreateNext(github.Version, string, github.ReleaseOptions) (github.Tag, error)
+	Init(string, github.ReleaseOptions) (github.Tag, error)
+	UploadAssets(github.Tag, []string) error
+	Publish(github.Tag) error
+	Discard(github.Tag) error
+	CheckGoModule(github.Version, github.Tag, string) (string, error)
+	GetFloatingTags(github.Tag) ([]string, error)
+	MoveFloatingTags(github.Tag, []string) ([]string, error)
+}
+
+type changelogService interface {
+	Get(github.Tag, *github.Tag, string) (github.Changelog, error)
+	Generate() (string, error)
+	Describe(string, string) (github.Tag, error)
+}
+
+type commentService interface {
+	Upsert(int, string) error
 }
 
+// getCredentials returns the repository and the github token,
+// checkCredentials ensures they are defined
 func getCredentials() (owner string, repository string, token string) {
-	for _, s := range []struct {
-		name string
-		ptr  *string
-	}{
-		{
-			githubOwner,
-			&owner,
-		},
-		{
-			githubRepository,
-			&repository,
-		},
-		{
-			githubToken,
-			&token,
-		},
-	} {
-		*s.ptr = viper.GetString(s.name)
+	dir, _ := os.Getwd()
+	owner, repository, _ = getRepository(dir)
+
+	return owner, repository, viper.GetString(tokenKey)
+}
+
+// newScheme creates the versioning scheme of tags from the configuration
+func newScheme() (github.Scheme, error) {
+	var leadingV *bool
+
+	if v := viper.GetString(leadingVKey); v != "" {
+		b, err := strconv.ParseBool(v)
+		if err != nil {
+			return nil, fmt.Errorf("%s is not a valid leading v setting, use true or false", v)
+		}
+
+		leadingV = &b
+	}
+
+	format, err := github.NewTagFormat(viper.GetString(tagTemplateKey), leadingV)
+	if err != nil {
+		return nil, err
 	}
-	return
+
+	scheme, err := github.NewSchemeFromString(viper.GetString(schemeKey))
+	if err != nil {
+		return nil, err
+	}
+
+	initialVersion := viper.GetString(initialVersionKey)
+
+	switch s := scheme.(type) {
+	case github.SemverScheme:
+		s.TagFormat = format
+		s.ZeroMajorAsMinor = viper.GetBool(zeroMajorAsMinorKey)
+
+		if initialVersion != "" {
+			tag, err := github.NewTagFromString(initialVersion)
+			if err != nil {
+				return nil, fmt.Errorf("initial version %s is not valid : %s", initialVersion, err)
+			}
+
+			s.InitialVersion = &tag
+		}
+
+		return s, nil
+	case github.CalVerScheme:
+		if initialVersion != "" {
+			return nil, fmt.Errorf("an initial version can't be defined with a calver scheme")
+		}
+
+		s.TagFormat = format
+
+		return s, nil
+	}
+
+	return scheme, nil
+}
+
+// getScheme returns the versioning scheme of tags,
+// it falls back to semver since setupCommand validates it
+func getScheme() github.Scheme {
+	scheme, err := newScheme()
+	if err != nil {
+		return github.SemverScheme{}
+	}
+
+	return scheme
 }
 
 func getSemverLabelService() github.SemverLabelService {
@@ -43,5 +121,13 @@ func getSemverLabelService() github.SemverLabelService {
 }
 
 func getReleaseService() github.ReleaseService {
-	return github.NewReleaseService(getCredentials())
+	return github.NewReleaseService(getCredentials()).WithScheme(getScheme())
+}
+
+func getCommentService() github.CommentService {
+	return github.NewCommentService(getCredentials())
+}
+
+func getChangelogService() github.ChangelogService {
+	return github.NewChangelogService(getCredentials()).WithScheme(getScheme())
 }
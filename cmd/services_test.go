@@ -5,12 +5,13 @@ import (
 	"testing"
 
 	"github.com/antham/versem/github"
-	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 )
 
 type semverServiceMock struct {
 	version         github.Version
+	pullRequest     *github.PullRequest
+	components      []github.ComponentVersion
 	err             error
 	methodCallCount map[string]int
 }
@@ -25,27 +26,163 @@ func (s semverServiceMock) GetFromCommit(string) (github.Version, error) {
 	return s.version, s.err
 }
 
+func (s semverServiceMock) GetPullRequestFromCommit(string) (*github.PullRequest, error) {
+	s.methodCallCount["GetPullRequestFromCommit"]++
+	return s.pullRequest, s.err
+}
+
+func (s semverServiceMock) GetComponentsFromPullRequest(int, []github.Component) ([]github.ComponentVersion, error) {
+	s.methodCallCount["GetComponentsFromPullRequest"]++
+	return s.components, s.err
+}
+
+func (s semverServiceMock) GetComponentsFromCommit(string, []github.Component) ([]github.ComponentVersion, error) {
+	s.methodCallCount["GetComponentsFromCommit"]++
+	return s.components, s.err
+}
+
 func (s semverServiceMock) CreateList() error {
 	s.methodCallCount["CreateList"]++
 	return s.err
 }
 
 type releaseServiceMock struct {
+	commitTag       *github.Tag
+	commitTagErr    error
+	bumpCommit      string
+	bumpCommitTag   *github.Tag
+	bumpCommitErr   error
+	status          github.ReleaseStatus
+	statusErr       error
+	lastTag         *github.Tag
+	nextErr         error
 	tag             github.Tag
 	err             error
+	uploadErr       error
+	publishErr      error
+	discardErr      error
+	goModuleWarning string
+	goModuleErr     error
+	aliases         []string
+	floatingErr     error
+	moved           []string
+	moveErr         error
+	options         *github.ReleaseOptions
+	assets          *[]string
 	methodCallCount map[string]int
 }
 
-func (r releaseServiceMock) CreateNext(version github.Version, targetCommitish string) (github.Tag, error) {
+func (r releaseServiceMock) GetNext(version github.Version, component *github.Component) (*github.Tag, github.Tag, error) {
+	r.methodCallCount["GetNext"]++
+	return r.lastTag, r.tag, r.nextErr
+}
+
+func (r releaseServiceMock) GetFromCommit(commitSha string, component *github.Component) (*github.Tag, error) {
+	r.methodCallCount["GetFromCommit"]++
+	if r.bumpCommit != "" && commitSha == r.bumpCommit {
+		return r.bumpCommitTag, r.commitTagErr
+	}
+	return r.commitTag, r.commitTagErr
+}
+
+func (r releaseServiceMock) GetBumpCommit(commitSha string, branch string) (string, error) {
+	r.methodCallCount["GetBumpCommit"]++
+	return r.bumpCommit, r.bumpCommitErr
+}
+
+func (r releaseServiceMock) GetReleaseStatus(tag github.Tag) (github.ReleaseStatus, error) {
+	r.methodCallCount["GetReleaseStatus"]++
+	return r.status, r.statusErr
+}
+
+func (r releaseServiceMock) CreateNext(version github.Version, targetCommitish string, options github.ReleaseOptions) (github.Tag, error) {
 	r.methodCallCount["CreateNext"]++
+	if r.options != nil {
+		*r.options = options
+	}
 	return r.tag, r.err
 }
 
+func (r releaseServiceMock) Init(targetCommitish string, options github.ReleaseOptions) (github.Tag, error) {
+	r.methodCallCount["Init"]++
+	if r.options != nil {
+		*r.options = options
+	}
+	return r.tag, r.err
+}
+
+func (r releaseServiceMock) UploadAssets(tag github.Tag, paths []string) error {
+	r.methodCallCount["UploadAssets"]++
+	if r.assets != nil {
+		*r.assets = paths
+	}
+	return r.uploadErr
+}
+
+func (r releaseServiceMock) Publish(tag github.Tag) error {
+	r.methodCallCount["Publish"]++
+	return r.publishErr
+}
+
+func (r releaseServiceMock) Discard(tag github.Tag) error {
+	r.methodCallCount["Discard"]++
+	return r.discardErr
+}
+
+type changelogServiceMock struct {
+	changelog       github.Changelog
+	content         string
+	tag             github.Tag
+	err             error
+	methodCallCount map[string]int
+}
+
+func (c changelogServiceMock) Get(tag github.Tag, previousTag *github.Tag, commitSha string) (github.Changelog, error) {
+	c.methodCallCount["Get"]++
+	return c.changelog, c.err
+}
+
+func (c changelogServiceMock) Generate() (string, error) {
+	c.methodCallCount["Generate"]++
+	return c.content, c.err
+}
+
+func (c changelogServiceMock) Describe(commitSha string, identifier string) (github.Tag, error) {
+	c.methodCallCount["Describe"]++
+	return c.tag, c.err
+}
+
+func (r releaseServiceMock) GetFloatingTags(tag github.Tag) ([]string, error) {
+	r.methodCallCount["GetFloatingTags"]++
+	return r.aliases, r.floatingErr
+}
+
+func (r releaseServiceMock) MoveFloatingTags(tag github.Tag, aliases []string) ([]string, error) {
+	r.methodCallCount["MoveFloatingTags"]++
+	return r.moved, r.moveErr
+}
+
+func (r releaseServiceMock) CheckGoModule(version github.Version, tag github.Tag, ref string) (string, error) {
+	r.methodCallCount["CheckGoModule"]++
+	return r.goModuleWarning, r.goModuleErr
+}
+
+type commentServiceMock struct {
+	body            *string
+	err             error
+	methodCallCount map[string]int
+}
+
+func (c commentServiceMock) Upsert(pullRequestNumber int, body string) error {
+	c.methodCallCount["Upsert"]++
+	*c.body = body
+	return c.err
+}
+
 func TestGetCredentials(t *testing.T) {
 	os.Setenv("GITHUB_OWNER", "antham")
 	os.Setenv("GITHUB_REPOSITORY", "versem")
 	os.Setenv("GITHUB_TOKEN", "token")
-	viper.AutomaticEnv()
 	owner, repository, token := getCredentials()
 	assert.Equal(t, "antham", owner)
 	assert.Equal(t, "versem", repository)
@@ -54,3 +191,85 @@ func TestGetCredentials(t *testing.T) {
 	os.Unsetenv("GITHUB_REPOSITORY")
 	os.Unsetenv("GITHUB_TOKEN")
 }
+
+func TestGetScheme(t *testing.T) {
+	os.Setenv("VERSEM_SCHEME", "calver:YYYY.0M.MICRO")
+	assert.Equal(t, "YYYY.0M.MICRO", getScheme().First(github.MINOR).Format)
+	os.Setenv("VERSEM_SCHEME", "whatever")
+	assert.Equal(t, github.SemverScheme{}, getScheme())
+	os.Unsetenv("VERSEM_SCHEME")
+	assert.Equal(t, github.SemverScheme{}, getScheme())
+	os.Setenv("VERSEM_ZERO_MAJOR_AS_MINOR", "true")
+	assert.Equal(t, github.SemverScheme{ZeroMajorAsMinor: true}, getScheme())
+	os.Unsetenv("VERSEM_ZERO_MAJOR_AS_MINOR")
+}
+
+func TestNewScheme(t *testing.T) {
+	leadingV := true
+
+	scenarios := []struct {
+		name string
+		env  map[string]string
+		test func(github.Scheme, error)
+	}{
+		{
+			"Default scheme",
+			map[string]string{},
+			func(scheme github.Scheme, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, github.SemverScheme{}, scheme)
+			},
+		},
+		{
+			"Semver scheme with a tag format and an initial version",
+			map[string]string{"VERSEM_INITIAL_VERSION": "0.1.0", "VERSEM_LEADING_V": "true", "VERSEM_TAG_TEMPLATE": "release-{version}"},
+			func(scheme github.Scheme, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, github.SemverScheme{TagFormat: github.TagFormat{Template: "release-{version}", LeadingV: &leadingV}, InitialVersion: &github.Tag{Minor: 1}}, scheme)
+			},
+		},
+		{
+			"Leading v setting is not valid",
+			map[string]string{"VERSEM_LEADING_V": "maybe"},
+			func(scheme github.Scheme, err error) {
+				assert.EqualError(t, err, "maybe is not a valid leading v setting, use true or false")
+			},
+		},
+		{
+			"Tag template is not valid",
+			map[string]string{"VERSEM_TAG_TEMPLATE": "release"},
+			func(scheme github.Scheme, err error) {
+				assert.EqualError(t, err, "tag template release must contain {version} once")
+			},
+		},
+		{
+			"Initial version is not valid",
+			map[string]string{"VERSEM_INITIAL_VERSION": "1.0"},
+			func(scheme github.Scheme, err error) {
+				assert.EqualError(t, err, "initial version 1.0 is not valid : 1.0 is not a valid semver tag")
+			},
+		},
+		{
+			"Initial version with a calver scheme",
+			map[string]string{"VERSEM_SCHEME": "calver:YYYY.0M.MICRO", "VERSEM_INITIAL_VERSION": "1.0.0"},
+			func(scheme github.Scheme, err error) {
+				assert.EqualError(t, err, "an initial version can't be defined with a calver scheme")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			for key, value := range scenario.env {
+				os.Setenv(key, value)
+			}
+
+			scenario.test(newScheme())
+
+			for key := range scenario.env {
+				os.Unsetenv(key)
+			}
+		})
+	}
+}
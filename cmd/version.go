@@ -8,9 +8,10 @@ var appVersion = ""
 
 // versionCmd represents the version command
 var versionCmd = &cobra.Command{
-	Use:   "version",
-	Short: "App version",
-	Run:   setupVersionCmdFunc(version),
+	Use:         "version",
+	Short:       "App version",
+	Annotations: map[string]string{offlineAnnotation: ""},
+	Run:         setupVersionCmdFunc(version),
 }
 
 func setupVersionCmdFunc(f func(messageHandler, *cobra.Command, []string)) func(*cobra.Command, []string) {
@@ -0,0 +1,45 @@
+package cmd
+
+import (
+	"regexp"
+
+	"github.com/antham/versem/github"
+
+	"github.com/spf13/cobra"
+)
+
+var versionDescribeCmd = &cobra.Command{
+	Use:   "describe [commitSha]",
+	Short: "Compute the pre-release version of a commit from the highest tag it descends from",
+	Run:   setupVersionDescribeCmdFunc(versionDescribe),
+}
+
+func init() {
+	versionDescribeCmd.Flags().String("identifier", "next", "pre-release identifier")
+	versionCmd.AddCommand(versionDescribeCmd)
+}
+
+func setupVersionDescribeCmdFunc(f func(messageHandler, changelogService, *cobra.Command, []string)) func(*cobra.Command, []string) {
+	return func(cmd *cobra.Command, args []string) {
+		msgHandler := newMessageHandler()
+		f(msgHandler, getChangelogService(), cmd, args)
+	}
+}
+
+func versionDescribe(msgHandler messageHandler, changelogService changelogService, cmd *cobra.Command, args []string) {
+	if len(args) != 1 || !regexp.MustCompile("^[0-9a-f]{40}$").MatchString(args[0]) {
+		msgHandler.errorFatalStr("provide a full commit sha as first argument")
+	}
+
+	identifier, _ := cmd.Flags().GetString("identifier")
+	if !github.PreReleaseIdentifierRegexp.MatchString(identifier) {
+		msgHandler.errorFatalStr("%s is not a valid pre-release identifier", identifier)
+	}
+
+	tag, err := changelogService.Describe(args[0], identifier)
+	if err != nil {
+		msgHandler.errorFatal(err)
+	}
+
+	msgHandler.success(tag.String())
+}
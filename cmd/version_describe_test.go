@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/antham/versem/github"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/spf13/cobra"
+)
+
+func TestVersionDescribe(t *testing.T) {
+	type scenario struct {
+		name                string
+		args                []string
+		flags               []string
+		getChangelogService func() changelogService
+		test                func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int)
+	}
+
+	scenarios := []scenario{
+		{
+			"No arguments provided",
+			[]string{},
+			[]string{},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "provide a full commit sha as first argument\n", stderr.String())
+				assert.Equal(t, 0, methodCallCount["Describe"])
+			},
+		},
+		{
+			"Invalid pre-release identifier",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--identifier", "next.1"},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "next.1 is not a valid pre-release identifier\n", stderr.String())
+				assert.Equal(t, 0, methodCallCount["Describe"])
+			},
+		},
+		{
+			"Failure occurred when describing the commit",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}, err: fmt.Errorf("can't fetch tags")}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 1, exitCode)
+				assert.Equal(t, "can't fetch tags\n", stderr.String())
+				assert.Equal(t, 1, methodCallCount["Describe"])
+			},
+		},
+		{
+			"Describe the commit",
+			[]string{"8a5ed8235d18fb0243493b82baf5d988459d24db"},
+			[]string{"--identifier", "beta"},
+			func() changelogService {
+				return changelogServiceMock{methodCallCount: map[string]int{}, tag: github.Tag{LeadingV: true, Major: 1, Minor: 5, PreRelease: "beta.12", BuildMetadata: "g8a5ed82"}}
+			},
+			func(exitCode int, stdout bytes.Buffer, stderr bytes.Buffer, methodCallCount map[string]int) {
+				assert.Equal(t, 0, exitCode)
+				assert.Equal(t, "v1.5.0-beta.12+g8a5ed82\n", stdout.String())
+				assert.Equal(t, 1, methodCallCount["Describe"])
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+
+			var code int
+			var stdout bytes.Buffer
+			var stderr bytes.Buffer
+			var w sync.WaitGroup
+
+			msgHandler := messageHandler{
+				func(exitCode int) {
+					panic(exitCode)
+				},
+				&stdout,
+				&stderr,
+			}
+
+			w.Add(1)
+
+			changelogService := scenario.getChangelogService()
+			cmd := &cobra.Command{}
+			cmd.Flags().String("identifier", "next", "")
+			assert.NoError(t, cmd.ParseFlags(scenario.flags))
+
+			go func() {
+				defer func() {
+					if r := recover(); r != nil {
+						code = r.(int)
+					}
+
+					w.Done()
+				}()
+
+				versionDescribe(msgHandler, changelogService, cmd, scenario.args)
+			}()
+
+			w.Wait()
+
+			scenario.test(code, stdout, stderr, changelogService.(changelogServiceMock).methodCallCount)
+		})
+	}
+}
@@ -0,0 +1,38 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"text/template"
+	"time"
+)
+
+var buildMetadataRegexp = regexp.MustCompile(`^[0-9A-Za-z-]+(\.[0-9A-Za-z-]+)*$`)
+
+// BuildMetadataData is given to the build metadata template
+type BuildMetadataData struct {
+	ShortSha string
+	Build    string
+	Date     time.Time
+}
+
+// NewBuildMetadata renders a build metadata template, the result
+// must be made of dot separated identifiers as semver requires
+func NewBuildMetadata(tmpl string, data BuildMetadataData) (string, error) {
+	t, err := template.New("build-metadata").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("build metadata template is not valid : %s", err)
+	}
+
+	b := strings.Builder{}
+	if err := t.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("can't render build metadata : %s", err)
+	}
+
+	if !buildMetadataRegexp.MatchString(b.String()) {
+		return "", fmt.Errorf("build metadata %s is not valid, use dot separated alphanumerics and hyphens", b.String())
+	}
+
+	return b.String(), nil
+}
@@ -0,0 +1,55 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewBuildMetadata(t *testing.T) {
+	data := BuildMetadataData{ShortSha: "8a5ed82", Build: "42", Date: time.Date(2019, 1, 1, 10, 0, 0, 0, time.UTC)}
+
+	scenarios := []struct {
+		name     string
+		template string
+		test     func(string, error)
+	}{
+		{
+			"Render the commit, the build number and the date",
+			`g{{.ShortSha}}.build{{.Build}}.{{.Date.Format "20060102"}}`,
+			func(metadata string, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "g8a5ed82.build42.20190101", metadata)
+			},
+		},
+		{
+			"Invalid template",
+			"{{.ShortSha",
+			func(metadata string, err error) {
+				assert.EqualError(t, err, "build metadata template is not valid : template: build-metadata:1: unclosed action")
+			},
+		},
+		{
+			"Unknown field",
+			"{{.Branch}}",
+			func(metadata string, err error) {
+				assert.Contains(t, err.Error(), "can't render build metadata : ")
+			},
+		},
+		{
+			"Rendered metadata is not valid",
+			"{{.ShortSha}}.",
+			func(metadata string, err error) {
+				assert.EqualError(t, err, "build metadata 8a5ed82. is not valid, use dot separated alphanumerics and hyphens")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(NewBuildMetadata(scenario.template, data))
+		})
+	}
+}
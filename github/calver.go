@@ -0,0 +1,152 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const calverMicro = "MICRO"
+
+// calverSegments lists the date segments supported in a calver format,
+// a segment starting with 0 is zero padded
+var calverSegments = map[string]struct {
+	pattern string
+	value   func(time.Time) int
+}{
+	"YYYY": {`\d{4}`, func(t time.Time) int { return t.Year() }},
+	"YY":   {`\d{1,3}`, func(t time.Time) int { return t.Year() - 2000 }},
+	"0Y":   {`\d{2,3}`, func(t time.Time) int { return t.Year() - 2000 }},
+	"MM":   {`\d{1,2}`, func(t time.Time) int { return int(t.Month()) }},
+	"0M":   {`\d{2}`, func(t time.Time) int { return int(t.Month()) }},
+	"WW":   {`\d{1,2}`, func(t time.Time) int { _, w := t.ISOWeek(); return w }},
+	"0W":   {`\d{2}`, func(t time.Time) int { _, w := t.ISOWeek(); return w }},
+	"DD":   {`\d{1,2}`, func(t time.Time) int { return t.Day() }},
+	"0D":   {`\d{2}`, func(t time.Time) int { return t.Day() }},
+}
+
+// CalVerScheme computes tags following calendar versioning, the format
+// is made of date segments like YYYY or 0M followed by a MICRO segment
+// counting the releases of a same period, for instance YYYY.0M.MICRO
+type CalVerScheme struct {
+	TagFormat
+	format   string
+	segments []string
+	regexp   *regexp.Regexp
+	now      func() time.Time
+}
+
+// NewCalVerScheme creates a new instance of CalVerScheme from a format
+func NewCalVerScheme(format string) (CalVerScheme, error) {
+	segments := strings.Split(format, ".")
+
+	if len(segments) < 2 || len(segments) > 3 {
+		return CalVerScheme{}, fmt.Errorf("calver format %s must have 2 or 3 segments", format)
+	}
+
+	patterns := []string{}
+
+	for i, segment := range segments {
+		if i == len(segments)-1 {
+			if segment != calverMicro {
+				return CalVerScheme{}, fmt.Errorf("calver format %s must end with %s", format, calverMicro)
+			}
+
+			patterns = append(patterns, `(\d+)`)
+			continue
+		}
+
+		s, ok := calverSegments[segment]
+		if !ok {
+			return CalVerScheme{}, fmt.Errorf("%s is not a valid date segment in calver format %s", segment, format)
+		}
+
+		patterns = append(patterns, "("+s.pattern+")")
+	}
+
+	return CalVerScheme{
+		TagFormat{},
+		format,
+		segments,
+		regexp.MustCompile(`^(v?)` + strings.Join(patterns, `\.`) + `$`),
+		time.Now,
+	}, nil
+}
+
+// First computes the tag of the current period without leading v,
+// whatever the version
+func (c CalVerScheme) First(version Version) Tag {
+	return c.Next(Tag{}, PATCH)
+}
+
+// Parse parses a tag matching the calver format
+func (c CalVerScheme) Parse(tag string) (Tag, error) {
+	version, _ := c.trim(tag)
+
+	matches := c.regexp.FindStringSubmatch(version)
+	if matches == nil {
+		return Tag{}, fmt.Errorf("%s is not a valid calver tag matching %s", tag, c.format)
+	}
+
+	t := Tag{LeadingV: matches[1] == "v", Format: c.format, Template: c.Template}
+
+	for i, match := range matches[2:] {
+		n, err := strconv.Atoi(match)
+		if err != nil {
+			return Tag{}, fmt.Errorf("%s is not a valid calver tag matching %s", tag, c.format)
+		}
+
+		*t.segment(i) = n
+	}
+
+	return t, nil
+}
+
+// Next computes the tag of the current period, the MICRO segment
+// is incremented when the previous tag belongs to the same period
+func (c CalVerScheme) Next(previousTag Tag, version Version) Tag {
+	if version == NORELEASE {
+		return previousTag
+	}
+
+	now := c.now().UTC()
+	nextTag := Tag{Prefix: previousTag.Prefix, LeadingV: previousTag.LeadingV, Format: c.format}
+	samePeriod := previousTag.Format == c.format
+	last := len(c.segments) - 1
+
+	for i, segment := range c.segments[:last] {
+		*nextTag.segment(i) = calverSegments[segment].value(now)
+		samePeriod = samePeriod && *nextTag.segment(i) == *previousTag.segment(i)
+	}
+
+	if samePeriod {
+		*nextTag.segment(last) = *previousTag.segment(last) + 1
+	}
+
+	return c.apply(nextTag)
+}
+
+// segment returns the field holding the nth segment of a calver tag
+func (t *Tag) segment(n int) *int {
+	return []*int{&t.Major, &t.Minor, &t.Patch}[n]
+}
+
+// formatCalVer renders the segments of a calver tag,
+// segments starting with 0 are zero padded
+func formatCalVer(t Tag) string {
+	segments := strings.Split(t.Format, ".")
+	parts := []string{}
+
+	for i, segment := range segments {
+		layout := "%d"
+		if strings.HasPrefix(segment, "0") {
+			layout = "%02d"
+		}
+
+		parts = append(parts, fmt.Sprintf(layout, *t.segment(i)))
+	}
+
+	return strings.Join(parts, ".")
+}
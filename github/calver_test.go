@@ -0,0 +1,176 @@
+package github
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewCalVerScheme(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		format string
+		test   func(CalVerScheme, error)
+	}{
+		{
+			"Format with a single segment",
+			"MICRO",
+			func(scheme CalVerScheme, err error) {
+				assert.EqualError(t, err, "calver format MICRO must have 2 or 3 segments")
+			},
+		},
+		{
+			"Format without MICRO segment",
+			"YYYY.0M.0D",
+			func(scheme CalVerScheme, err error) {
+				assert.EqualError(t, err, "calver format YYYY.0M.0D must end with MICRO")
+			},
+		},
+		{
+			"Format with an unknown segment",
+			"YYYY.QQ.MICRO",
+			func(scheme CalVerScheme, err error) {
+				assert.EqualError(t, err, "QQ is not a valid date segment in calver format YYYY.QQ.MICRO")
+			},
+		},
+		{
+			"Valid format",
+			"YY.0W.MICRO",
+			func(scheme CalVerScheme, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []string{"YY", "0W", "MICRO"}, scheme.segments)
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(NewCalVerScheme(scenario.format))
+		})
+	}
+}
+
+func TestCalVerSchemeParse(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		format string
+		tag    string
+		test   func(Tag, error)
+	}{
+		{
+			"Parse a tag with a zero padded month",
+			"YYYY.0M.MICRO",
+			"2026.01.3",
+			func(tag Tag, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, Tag{Major: 2026, Minor: 1, Patch: 3, Format: "YYYY.0M.MICRO"}, tag)
+				assert.Equal(t, "2026.01.3", tag.String())
+			},
+		},
+		{
+			"Parse a tag with a leading v",
+			"YY.MICRO",
+			"v26.12",
+			func(tag Tag, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, Tag{LeadingV: true, Major: 26, Minor: 12, Format: "YY.MICRO"}, tag)
+				assert.Equal(t, "v26.12", tag.String())
+			},
+		},
+		{
+			"Parse a tag not matching the format",
+			"YYYY.0M.MICRO",
+			"2026.1.3",
+			func(tag Tag, err error) {
+				assert.EqualError(t, err, "2026.1.3 is not a valid calver tag matching YYYY.0M.MICRO")
+			},
+		},
+		{
+			"Parse a semver tag",
+			"YYYY.MM.MICRO",
+			"v1.2.3",
+			func(tag Tag, err error) {
+				assert.EqualError(t, err, "v1.2.3 is not a valid calver tag matching YYYY.MM.MICRO")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scheme, err := NewCalVerScheme(scenario.format)
+			assert.NoError(t, err)
+			scenario.test(scheme.Parse(scenario.tag))
+		})
+	}
+}
+
+func TestCalVerSchemeNext(t *testing.T) {
+	scheme, err := NewCalVerScheme("YYYY.0M.MICRO")
+	assert.NoError(t, err)
+	scheme.now = func() time.Time {
+		return time.Date(2026, 10, 19, 10, 0, 0, 0, time.UTC)
+	}
+
+	scenarios := []struct {
+		name        string
+		previousTag Tag
+		version     Version
+		expected    string
+	}{
+		{
+			"Previous tag has another scheme",
+			Tag{LeadingV: true, Major: 1, Minor: 2},
+			MINOR,
+			"v2026.10.0",
+		},
+		{
+			"Previous tag released during the same month",
+			Tag{Prefix: "api/", Major: 2026, Minor: 10, Patch: 3, Format: "YYYY.0M.MICRO"},
+			PATCH,
+			"api/2026.10.4",
+		},
+		{
+			"Previous tag released the month before",
+			Tag{LeadingV: true, Major: 2026, Minor: 9, Patch: 7, Format: "YYYY.0M.MICRO"},
+			MAJOR,
+			"v2026.10.0",
+		},
+		{
+			"Nothing to release",
+			Tag{Major: 2026, Minor: 9, Patch: 7, Format: "YYYY.0M.MICRO"},
+			NORELEASE,
+			"2026.09.7",
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			assert.Equal(t, scenario.expected, scheme.Next(scenario.previousTag, scenario.version).String())
+		})
+	}
+}
+
+func TestCalVerSchemeFirst(t *testing.T) {
+	scheme, err := NewCalVerScheme("YY.0M.MICRO")
+	assert.NoError(t, err)
+	scheme.now = func() time.Time {
+		return time.Date(2026, 3, 19, 10, 0, 0, 0, time.UTC)
+	}
+
+	assert.Equal(t, "26.03.0", scheme.First(NORELEASE).String())
+
+	scheme.TagFormat = TagFormat{Template: "release-{version}"}
+	tag := scheme.First(MAJOR)
+	assert.Equal(t, "release-26.03.0", tag.String())
+
+	parsedTag, err := scheme.Parse("release-26.03.0")
+	assert.NoError(t, err)
+	assert.Equal(t, tag, parsedTag)
+
+	_, err = scheme.Parse("26.03.0")
+	assert.EqualError(t, err, "26.03.0 is not a valid calver tag matching YY.0M.MICRO")
+}
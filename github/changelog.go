@@ -0,0 +1,349 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+// ChangelogFilename is the changelog file maintained in the repository
+const ChangelogFilename = "CHANGELOG.md"
+
+const changelogHeader = `# Changelog
+
+All notable changes to this project will be documented in this file.
+
+The format is based on [Keep a Changelog](https://keepachangelog.com/en/1.0.0/),
+and this project adheres to [Semantic Versioning](https://semver.org/spec/v2.0.0.html).
+`
+
+// ChangelogGroup gathers the pull requests released with a same label
+type ChangelogGroup struct {
+	Name         string
+	PullRequests []PullRequest
+}
+
+// Changelog lists the pull requests merged for a tag grouped by semver label
+type Changelog struct {
+	Tag    Tag
+	Date   time.Time
+	Groups []ChangelogGroup
+}
+
+// Body renders the groups of pull requests
+func (c Changelog) Body() string {
+	groups := []string{}
+
+	for _, group := range c.Groups {
+		b := strings.Builder{}
+		b.WriteString(fmt.Sprintf("### %s\n\n", group.Name))
+
+		for _, pr := range group.PullRequests {
+			b.WriteString(fmt.Sprintf("- %s (#%d)\n", pr.Title, pr.Number))
+		}
+
+		groups = append(groups, b.String())
+	}
+
+	return strings.Join(groups, "\n")
+}
+
+// Section renders the changelog as a Keep a Changelog section
+func (c Changelog) Section() string {
+	heading := fmt.Sprintf("## [%s] - %s\n", c.Tag, c.Date.Format("2006-01-02"))
+
+	if len(c.Groups) == 0 {
+		return heading
+	}
+
+	return heading + "\n" + c.Body()
+}
+
+// Prepend adds the section on top of the sections of a changelog file,
+// the file is created when the content is empty and left untouched
+// when it already has a section for the tag
+func (c Changelog) Prepend(content string) string {
+	if strings.TrimSpace(content) == "" {
+		return changelogHeader + "\n" + c.Section()
+	}
+
+	if strings.Contains(content, fmt.Sprintf("## [%s]", c.Tag)) {
+		return content
+	}
+
+	if index := strings.Index(content, "\n## "); index != -1 {
+		return content[:index+1] + c.Section() + "\n" + content[index+1:]
+	}
+
+	return strings.TrimRight(content, "\n") + "\n\n" + c.Section()
+}
+
+// ChangelogService builds changelogs from pull requests merged between semver tags
+type ChangelogService struct {
+	owner              string
+	repository         string
+	client             *github.Client
+	semverLabelService SemverLabelService
+	scheme             Scheme
+}
+
+// NewChangelogService creates a new instance of ChangelogService
+func NewChangelogService(owner string, repository string, token string) ChangelogService {
+	return ChangelogService{
+		owner,
+		repository,
+		github.NewClient(
+			oauth2.NewClient(
+				context.Background(),
+				oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			),
+		),
+		NewSemverLabelService(owner, repository, token),
+		SemverScheme{},
+	}
+}
+
+// WithScheme returns a copy of the service parsing tags with a scheme
+func (c ChangelogService) WithScheme(scheme Scheme) ChangelogService {
+	c.scheme = scheme
+	return c
+}
+
+// Get builds the changelog of a tag on a commit from the pull requests
+// merged since the previous tag, or since the beginning when there is none
+func (c ChangelogService) Get(tag Tag, previousTag *Tag, commitSha string) (Changelog, error) {
+	return c.get(tag, previousTag, commitSha, c.semverLabelService.getCommitPullRequests)
+}
+
+// get builds the changelog of a tag finding the pull requests of each commit with a lookup function
+func (c ChangelogService) get(tag Tag, previousTag *Tag, commitSha string, getCommitPullRequests func(string) ([]commitPullRequest, error)) (Changelog, error) {
+	commit, _, err := c.client.Git.GetCommit(context.Background(), c.owner, c.repository, commitSha)
+	if err != nil {
+		return Changelog{}, fmt.Errorf("can't fetch commit %s : %s", commitSha, err)
+	}
+
+	commitShas, err := c.getCommits(previousTag, commitSha)
+	if err != nil {
+		return Changelog{}, err
+	}
+
+	groups := map[Version]*ChangelogGroup{
+		MAJOR:          {Name: "Major"},
+		MINOR:          {Name: "Minor"},
+		PATCH:          {Name: "Patch"},
+		UNVALIDVERSION: {Name: "Other"},
+	}
+	seen := map[int]bool{}
+
+	for _, sha := range commitShas {
+		pullRequests, err := getCommitPullRequests(sha)
+		if err != nil {
+			return Changelog{}, err
+		}
+
+		for _, pr := range pullRequests {
+			if seen[pr.Number] {
+				continue
+			}
+			seen[pr.Number] = true
+
+			version, err := extractSemverLabels(pr.Labels)
+			if err != nil || version == NORELEASE {
+				version = UNVALIDVERSION
+			}
+
+			groups[version].PullRequests = append(groups[version].PullRequests, PullRequest{Number: pr.Number, Title: pr.Title, Body: pr.Body})
+		}
+	}
+
+	changelog := Changelog{Tag: tag, Date: commit.GetCommitter().GetDate()}
+
+	for _, version := range []Version{MAJOR, MINOR, PATCH, UNVALIDVERSION} {
+		if len(groups[version].PullRequests) > 0 {
+			changelog.Groups = append(changelog.Groups, *groups[version])
+		}
+	}
+
+	return changelog, nil
+}
+
+// Generate rebuilds a whole changelog file from the history of tags
+func (c ChangelogService) Generate() (string, error) {
+	tags, err := c.listTags()
+	if err != nil {
+		return "", err
+	}
+
+	pullRequests, err := c.listMergedPullRequests()
+	if err != nil {
+		return "", err
+	}
+
+	getCommitPullRequests := func(sha string) ([]commitPullRequest, error) {
+		return pullRequests[sha], nil
+	}
+
+	content := ""
+	var previousTag *Tag
+
+	for i := range tags {
+		changelog, err := c.get(tags[i].tag, previousTag, tags[i].sha, getCommitPullRequests)
+		if err != nil {
+			return "", err
+		}
+
+		content = changelog.Prepend(content)
+		previousTag = &tags[i].tag
+	}
+
+	return content, nil
+}
+
+// taggedCommit is a tag and the commit it points to
+type taggedCommit struct {
+	tag Tag
+	sha string
+}
+
+// listTags returns the tags following the scheme from the lowest to the highest
+func (c ChangelogService) listTags() ([]taggedCommit, error) {
+	tags := []taggedCommit{}
+	opts := &github.ListOptions{PerPage: 100}
+
+	for {
+		ts, res, err := c.client.Repositories.ListTags(context.Background(), c.owner, c.repository, opts)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch tags : %s", err)
+		}
+
+		for _, t := range ts {
+			tag, err := c.scheme.Parse(t.GetName())
+			if err != nil {
+				continue
+			}
+
+			tags = append(tags, taggedCommit{tag, t.GetCommit().GetSHA()})
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		opts.Page = res.NextPage
+	}
+
+	sort.SliceStable(tags, func(i int, j int) bool {
+		return tagLess(tags[i].tag, tags[j].tag)
+	})
+
+	return tags, nil
+}
+
+// listMergedPullRequests returns the merged pull requests indexed by their merge commit sha
+func (c ChangelogService) listMergedPullRequests() (map[string][]commitPullRequest, error) {
+	pullRequests := map[string][]commitPullRequest{}
+	opts := &github.PullRequestListOptions{State: "closed", ListOptions: github.ListOptions{PerPage: 100}}
+
+	for {
+		prs, res, err := c.client.PullRequests.List(context.Background(), c.owner, c.repository, opts)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch pull requests : %s", err)
+		}
+
+		for _, pr := range prs {
+			if pr.MergedAt == nil || pr.GetMergeCommitSHA() == "" {
+				continue
+			}
+
+			labels := []github.Label{}
+			for _, label := range pr.Labels {
+				labels = append(labels, *label)
+			}
+
+			sha := pr.GetMergeCommitSHA()
+			pullRequests[sha] = append(pullRequests[sha], commitPullRequest{Number: pr.GetNumber(), Title: pr.GetTitle(), Body: pr.GetBody(), Labels: labels})
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		opts.Page = res.NextPage
+	}
+
+	return pullRequests, nil
+}
+
+// getCommits returns the shas of commits reachable from the commit
+// and not from the previous tag
+func (c ChangelogService) getCommits(previousTag *Tag, commitSha string) ([]string, error) {
+	if previousTag == nil {
+		return c.listCommits(commitSha, "")
+	}
+
+	comparison, _, err := c.client.Repositories.CompareCommits(context.Background(), c.owner, c.repository, previousTag.String(), commitSha)
+	if err != nil {
+		return nil, fmt.Errorf("can't compare %s with commit %s : %s", previousTag, commitSha, err)
+	}
+
+	return c.getComparedCommits(comparison, commitSha)
+}
+
+// getComparedCommits returns the shas of the commits of a comparison, they are
+// listed up to the merge base when the comparison is truncated by the api
+func (c ChangelogService) getComparedCommits(comparison *github.CommitsComparison, commitSha string) ([]string, error) {
+	if comparison.GetTotalCommits() > len(comparison.Commits) {
+		return c.listCommits(commitSha, comparison.GetMergeBaseCommit().GetSHA())
+	}
+
+	shas := []string{}
+
+	for _, commit := range comparison.Commits {
+		shas = append(shas, commit.GetSHA())
+	}
+
+	return shas, nil
+}
+
+// listCommits returns the shas of the commits of the history of a commit from
+// the oldest, the listing stops at the stop commit when one is given
+func (c ChangelogService) listCommits(commitSha string, stopSha string) ([]string, error) {
+	shas := []string{}
+	opts := &github.CommitsListOptions{SHA: commitSha, ListOptions: github.ListOptions{PerPage: 100}}
+
+	stopped := false
+
+	for !stopped {
+		commits, res, err := c.client.Repositories.ListCommits(context.Background(), c.owner, c.repository, opts)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch commits of %s : %s", commitSha, err)
+		}
+
+		for _, commit := range commits {
+			if commit.GetSHA() == stopSha {
+				stopped = true
+				break
+			}
+
+			shas = append(shas, commit.GetSHA())
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		opts.Page = res.NextPage
+	}
+
+	// commits are listed from the newest, pull requests are listed from the oldest
+	for i, j := 0, len(shas)-1; i < j; i, j = i+1, j-1 {
+		shas[i], shas[j] = shas[j], shas[i]
+	}
+
+	return shas, nil
+}
@@ -0,0 +1,101 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+	"golang.org/x/oauth2"
+)
+
+const commentMarker = "<!-- versem -->"
+
+// CommentService maintains a single comment
+// owned by versem on pull requests
+type CommentService struct {
+	owner      string
+	repository string
+	client     *github.Client
+}
+
+// NewCommentService creates a new instance of CommentService
+func NewCommentService(owner string, repository string, token string) CommentService {
+	return CommentService{
+		owner,
+		repository,
+		github.NewClient(
+			oauth2.NewClient(
+				context.Background(),
+				oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
+			),
+		),
+	}
+}
+
+// Upsert creates the versem comment on a pull request,
+// if the comment already exists it is edited in place
+func (c CommentService) Upsert(pullRequestNumber int, body string) error {
+	comment, err := c.find(pullRequestNumber)
+	if err != nil {
+		return err
+	}
+
+	body = commentMarker + "\n" + body
+
+	if comment == nil {
+		if _, _, err := c.client.Issues.CreateComment(context.Background(), c.owner, c.repository, pullRequestNumber, &github.IssueComment{Body: &body}); err != nil {
+			return fmt.Errorf("can't create comment on pull request #%d : %s", pullRequestNumber, err)
+		}
+		return nil
+	}
+
+	if _, _, err := c.client.Issues.EditComment(context.Background(), c.owner, c.repository, comment.GetID(), &github.IssueComment{Body: &body}); err != nil {
+		return fmt.Errorf("can't edit comment on pull request #%d : %s", pullRequestNumber, err)
+	}
+
+	return nil
+}
+
+// find returns the versem comment of a pull request created by the authenticated
+// user, comments of other users starting with the marker are ignored, installation
+// tokens like the one of github actions can't fetch their user, comments
+// of bots are considered instead
+func (c CommentService) find(pullRequestNumber int) (*github.IssueComment, error) {
+	isAuthor := func(user *github.User) bool {
+		return user.GetType() == "Bot"
+	}
+
+	user, res, err := c.client.Users.Get(context.Background(), "")
+
+	switch {
+	case err == nil:
+		isAuthor = func(u *github.User) bool {
+			return u.GetLogin() == user.GetLogin()
+		}
+	case res == nil || res.StatusCode != http.StatusForbidden:
+		return nil, fmt.Errorf("can't fetch authenticated user : %s", err)
+	}
+
+	options := &github.IssueListCommentsOptions{ListOptions: github.ListOptions{Page: 1, PerPage: 100}}
+
+	for {
+		comments, res, err := c.client.Issues.ListComments(context.Background(), c.owner, c.repository, pullRequestNumber, options)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch comments of pull request #%d : %s", pullRequestNumber, err)
+		}
+
+		for _, comment := range comments {
+			if isAuthor(comment.GetUser()) && strings.HasPrefix(comment.GetBody(), commentMarker) {
+				return comment, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+
+		options.Page = res.NextPage
+	}
+}
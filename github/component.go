@@ -0,0 +1,101 @@
+package github
+
+import (
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Component is an independently versioned part of a repository,
+// its tags are prefixed and it's released when a pull request
+// changes a file matching one of its path globs
+type Component struct {
+	Name   string
+	Prefix string
+	Paths  []string
+}
+
+// ComponentVersion is the version released for a component
+type ComponentVersion struct {
+	Component Component
+	Version   Version
+}
+
+// NewComponentFromString parses a component defined as name:prefix:glob[,glob...]
+func NewComponentFromString(component string) (Component, error) {
+	parts := strings.SplitN(component, ":", 3)
+
+	if len(parts) != 3 || parts[0] == "" || parts[1] == "" || parts[2] == "" {
+		return Component{}, fmt.Errorf("%s is not a valid component, use name:prefix:glob[,glob...]", component)
+	}
+
+	c := Component{Name: parts[0], Prefix: parts[1], Paths: strings.Split(parts[2], ",")}
+
+	for _, glob := range c.Paths {
+		if _, err := path.Match(strings.TrimSuffix(glob, "/**"), ""); err != nil {
+			return Component{}, fmt.Errorf("%s is not a valid glob pattern in component %s", glob, c.Name)
+		}
+	}
+
+	return c, nil
+}
+
+// Matches reports whether a file belongs to the component, a glob ending
+// with /** matches every file below a directory
+func (c Component) Matches(file string) bool {
+	for _, glob := range c.Paths {
+		if dir := strings.TrimSuffix(glob, "/**"); dir != glob {
+			for d := path.Dir(file); d != "." && d != "/"; d = path.Dir(d) {
+				if ok, _ := path.Match(dir, d); ok {
+					return true
+				}
+			}
+
+			continue
+		}
+
+		if ok, _ := path.Match(glob, file); ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// FilterComponents returns the components at least one file belongs to
+func FilterComponents(components []Component, files []string) []Component {
+	touched := []Component{}
+
+	for _, c := range components {
+		for _, f := range files {
+			if c.Matches(f) {
+				touched = append(touched, c)
+				break
+			}
+		}
+	}
+
+	return touched
+}
+
+// CheckComponents ensures components can be told apart in name:version
+// labels, their names must be unique and differ from semver labels
+func CheckComponents(components []Component) error {
+	names := map[string]bool{}
+
+	for _, c := range components {
+		for _, label := range []string{noreleaseStr, patchStr, minorStr, majorStr} {
+			if strings.EqualFold(c.Name, label) {
+				return fmt.Errorf("component %s conflicts with the %s semver label", c.Name, label)
+			}
+		}
+
+		if names[c.Name] {
+			return fmt.Errorf("component %s is defined twice", c.Name)
+		}
+
+		names[c.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,81 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewComponentFromString(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		argument string
+		test     func(Component, error)
+	}{
+		{
+			"Parse a component",
+			"api:api/:api/**,proto/*.proto",
+			func(component Component, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, Component{Name: "api", Prefix: "api/", Paths: []string{"api/**", "proto/*.proto"}}, component)
+			},
+		},
+		{
+			"Component without glob",
+			"api:api/",
+			func(component Component, err error) {
+				assert.EqualError(t, err, "api:api/ is not a valid component, use name:prefix:glob[,glob...]")
+			},
+		},
+		{
+			"Component with an empty prefix",
+			"api::api/**",
+			func(component Component, err error) {
+				assert.EqualError(t, err, "api::api/** is not a valid component, use name:prefix:glob[,glob...]")
+			},
+		},
+		{
+			"Component with an invalid glob",
+			"api:api/:api/[",
+			func(component Component, err error) {
+				assert.EqualError(t, err, "api/[ is not a valid glob pattern in component api")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(NewComponentFromString(scenario.argument))
+		})
+	}
+}
+
+func TestComponentMatches(t *testing.T) {
+	component := Component{Name: "api", Prefix: "api/", Paths: []string{"services/api/**", "proto/*.proto"}}
+
+	assert.True(t, component.Matches("services/api/main.go"))
+	assert.True(t, component.Matches("services/api/handler/user.go"))
+	assert.True(t, component.Matches("proto/user.proto"))
+	assert.False(t, component.Matches("proto/v1/user.proto"))
+	assert.False(t, component.Matches("services/cli/main.go"))
+	assert.False(t, component.Matches("services/api"))
+}
+
+func TestFilterComponents(t *testing.T) {
+	api := Component{Name: "api", Prefix: "api/", Paths: []string{"api/**"}}
+	cli := Component{Name: "cli", Prefix: "cli/", Paths: []string{"cli/**"}}
+	web := Component{Name: "web", Prefix: "web/", Paths: []string{"web/**"}}
+
+	assert.Equal(t, []Component{api, web}, FilterComponents([]Component{api, cli, web}, []string{"web/index.html", "api/main.go", "README.md"}))
+	assert.Equal(t, []Component{}, FilterComponents([]Component{api, cli, web}, []string{"README.md"}))
+}
+
+func TestCheckComponents(t *testing.T) {
+	api := Component{Name: "api", Prefix: "api/", Paths: []string{"api/**"}}
+	cli := Component{Name: "cli", Prefix: "cli/", Paths: []string{"cli/**"}}
+
+	assert.NoError(t, CheckComponents([]Component{api, cli}))
+	assert.EqualError(t, CheckComponents([]Component{api, cli, api}), "component api is defined twice")
+	assert.EqualError(t, CheckComponents([]Component{api, {Name: "Minor", Prefix: "minor/", Paths: []string{"minor/**"}}}), "component Minor conflicts with the minor semver label")
+}
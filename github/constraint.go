@@ -0,0 +1,270 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+var partialVersionRegexp = regexp.MustCompile(`^v?(\d+|[xX*])(?:\.(\d+|[xX*])(?:\.(\d+|[xX*])(?:-([0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*))?(?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)?)?$`)
+
+var operatorSpaceRegexp = regexp.MustCompile(`(>=|<=|>|<|=|\^|~)\s+`)
+
+// comparator checks a version against a tag, implicit comparators
+// are the bounds computed from a range, they never allow pre-releases
+type comparator struct {
+	operator string
+	tag      Tag
+	implicit bool
+}
+
+func (c comparator) check(tag Tag) bool {
+	n := tag.Compare(c.tag)
+
+	switch c.operator {
+	case "<":
+		return n < 0
+	case "<=":
+		return n <= 0
+	case ">":
+		return n > 0
+	case ">=":
+		return n >= 0
+	}
+
+	return n == 0
+}
+
+// Constraint is a range of versions like ^1.2, ~1.2.3, >=1.0.0 <2.0.0,
+// 1.x or 1.2.3 - 2.3.4, space separated comparators must all be satisfied
+// and || separates alternatives, it follows npm rules
+type Constraint struct {
+	constraint string
+	sets       [][]comparator
+}
+
+// String returns the constraint as it was defined
+func (c Constraint) String() string {
+	return c.constraint
+}
+
+// NewConstraintFromString parses a constraint
+func NewConstraintFromString(constraint string) (Constraint, error) {
+	c := Constraint{constraint: constraint}
+
+	for _, set := range strings.Split(constraint, "||") {
+		comparators, err := parseComparatorSet(strings.TrimSpace(set))
+		if err != nil {
+			return Constraint{}, fmt.Errorf("%s is not a valid constraint : %s", constraint, err)
+		}
+
+		c.sets = append(c.sets, comparators)
+	}
+
+	return c, nil
+}
+
+// Satisfies reports whether a tag is in the range of a constraint,
+// a pre-release is only in the range when a comparator of the same
+// alternative targets a pre-release of the same version
+func (t Tag) Satisfies(constraint Constraint) bool {
+	for _, set := range constraint.sets {
+		if satisfiesSet(t, set) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func satisfiesSet(tag Tag, set []comparator) bool {
+	for _, c := range set {
+		if !c.check(tag) {
+			return false
+		}
+	}
+
+	if tag.PreRelease == "" {
+		return true
+	}
+
+	for _, c := range set {
+		if !c.implicit && c.tag.PreRelease != "" && c.tag.Major == tag.Major && c.tag.Minor == tag.Minor && c.tag.Patch == tag.Patch {
+			return true
+		}
+	}
+
+	return false
+}
+
+func parseComparatorSet(set string) ([]comparator, error) {
+	if parts := strings.SplitN(set, " - ", 2); len(parts) == 2 {
+		return parseHyphenRange(strings.TrimSpace(parts[0]), strings.TrimSpace(parts[1]))
+	}
+
+	comparators := []comparator{}
+
+	for _, token := range strings.Fields(operatorSpaceRegexp.ReplaceAllString(set, "$1")) {
+		cs, err := parseComparator(token)
+		if err != nil {
+			return nil, err
+		}
+
+		comparators = append(comparators, cs...)
+	}
+
+	return comparators, nil
+}
+
+// parseHyphenRange turns 1.2 - 2.3 into >=1.2.0 <2.4.0-0
+func parseHyphenRange(from string, to string) ([]comparator, error) {
+	lower, lowerParts, err := parsePartialVersion(from)
+	if err != nil {
+		return nil, err
+	}
+
+	upper, upperParts, err := parsePartialVersion(to)
+	if err != nil {
+		return nil, err
+	}
+
+	comparators := []comparator{}
+
+	if lowerParts > 0 {
+		comparators = append(comparators, comparator{">=", lower, false})
+	}
+
+	switch upperParts {
+	case 0:
+	case 3:
+		comparators = append(comparators, comparator{"<=", upper, false})
+	default:
+		comparators = append(comparators, comparator{"<", nextPartialVersion(upper, upperParts), true})
+	}
+
+	return comparators, nil
+}
+
+// parseComparator turns a comparator using a range operator or
+// a partial version into comparators on full versions
+func parseComparator(token string) ([]comparator, error) {
+	operator := ""
+
+	for _, op := range []string{">=", "<=", ">", "<", "=", "^", "~"} {
+		if strings.HasPrefix(token, op) {
+			operator = op
+			break
+		}
+	}
+
+	tag, parts, err := parsePartialVersion(strings.TrimPrefix(token, operator))
+	if err != nil {
+		return nil, err
+	}
+
+	none := []comparator{{"<", Tag{PreRelease: "0"}, true}}
+
+	if parts == 0 {
+		switch operator {
+		case "<", ">":
+			return none, nil
+		}
+
+		return []comparator{}, nil
+	}
+
+	switch operator {
+	case "^":
+		switch {
+		case tag.Major > 0 || parts == 1:
+			parts = 1
+		case tag.Minor > 0 || parts == 2:
+			parts = 2
+		}
+
+		return []comparator{{">=", tag, false}, {"<", nextPartialVersion(tag, parts), true}}, nil
+	case "~":
+		if parts == 3 {
+			parts = 2
+		}
+
+		return []comparator{{">=", tag, false}, {"<", nextPartialVersion(tag, parts), true}}, nil
+	case ">":
+		if parts == 3 {
+			return []comparator{{">", tag, false}}, nil
+		}
+
+		next := nextPartialVersion(tag, parts)
+		next.PreRelease = ""
+
+		return []comparator{{">=", next, false}}, nil
+	case ">=":
+		return []comparator{{">=", tag, false}}, nil
+	case "<":
+		if parts == 3 {
+			return []comparator{{"<", tag, false}}, nil
+		}
+
+		tag.PreRelease = "0"
+
+		return []comparator{{"<", tag, true}}, nil
+	case "<=":
+		if parts == 3 {
+			return []comparator{{"<=", tag, false}}, nil
+		}
+
+		return []comparator{{"<", nextPartialVersion(tag, parts), true}}, nil
+	}
+
+	if parts == 3 {
+		return []comparator{{"=", tag, false}}, nil
+	}
+
+	return []comparator{{">=", tag, false}, {"<", nextPartialVersion(tag, parts), true}}, nil
+}
+
+// parsePartialVersion parses a version where trailing parts can be
+// missing or wildcards, it returns the number of parts defined
+func parsePartialVersion(version string) (Tag, int, error) {
+	matches := partialVersionRegexp.FindStringSubmatch(version)
+	if matches == nil {
+		return Tag{}, 0, fmt.Errorf("%s is not a valid version", version)
+	}
+
+	tag := Tag{}
+	parts := 0
+
+	for i, ptr := range []*int{&tag.Major, &tag.Minor, &tag.Patch} {
+		n, err := strconv.Atoi(matches[i+1])
+		if err != nil {
+			break
+		}
+
+		*ptr = n
+		parts++
+	}
+
+	if parts == 3 {
+		tag.PreRelease = matches[4]
+	}
+
+	return tag, parts, nil
+}
+
+// nextPartialVersion returns the lowest pre-release of the version
+// following a partial version, like 1.3.0-0 for 1.2
+func nextPartialVersion(tag Tag, parts int) Tag {
+	next := Tag{PreRelease: "0"}
+
+	switch parts {
+	case 1:
+		next.Major = tag.Major + 1
+	case 2:
+		next.Major, next.Minor = tag.Major, tag.Minor+1
+	default:
+		next.Major, next.Minor, next.Patch = tag.Major, tag.Minor, tag.Patch+1
+	}
+
+	return next
+}
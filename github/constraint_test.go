@@ -0,0 +1,69 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagSatisfies(t *testing.T) {
+	scenarios := []struct {
+		constraint  string
+		satisfied   []string
+		unsatisfied []string
+	}{
+		{"^1.2", []string{"1.2.0", "v1.9.9"}, []string{"1.1.9", "2.0.0", "2.0.0-rc.1", "1.5.0-rc.1"}},
+		{"^1.2.3-rc.1", []string{"1.2.3-rc.2", "1.2.3", "1.3.0"}, []string{"1.2.3-beta.1", "1.3.0-rc.1"}},
+		{"^0.2.3", []string{"0.2.3", "0.2.9"}, []string{"0.3.0", "0.2.2"}},
+		{"^0.0.3", []string{"0.0.3"}, []string{"0.0.4"}},
+		{"^0", []string{"0.0.1", "0.9.0"}, []string{"1.0.0"}},
+		{"~1.2.3", []string{"1.2.3", "1.2.9"}, []string{"1.3.0", "1.2.2"}},
+		{"~1", []string{"1.0.0", "1.9.0"}, []string{"2.0.0"}},
+		{">=1.0.0 <2.0.0", []string{"1.0.0", "1.9.9"}, []string{"0.9.9", "2.0.0", "2.0.0-rc.1"}},
+		{">= 1.0.0 < 2", []string{"1.9.9"}, []string{"2.0.0-rc.1"}},
+		{"1.x", []string{"1.0.0", "1.9.9"}, []string{"2.0.0", "0.9.0"}},
+		{"1.2.*", []string{"1.2.0", "1.2.9"}, []string{"1.3.0"}},
+		{"*", []string{"0.0.0", "9.9.9"}, []string{"1.0.0-rc.1"}},
+		{"", []string{"1.0.0"}, []string{}},
+		{"1.2.3", []string{"1.2.3", "v1.2.3+g8a5ed82"}, []string{"1.2.4"}},
+		{"=1.2", []string{"1.2.5"}, []string{"1.3.0"}},
+		{">1.2", []string{"1.3.0"}, []string{"1.2.9"}},
+		{">1.2.3", []string{"1.2.4"}, []string{"1.2.3"}},
+		{"<1.2", []string{"1.1.9"}, []string{"1.2.0", "1.2.0-rc.1"}},
+		{"<=1.2", []string{"1.2.9"}, []string{"1.3.0"}},
+		{"<=1.2.3", []string{"1.2.3"}, []string{"1.2.4"}},
+		{"<*", []string{}, []string{"0.0.0"}},
+		{"1.2.3 - 2.3", []string{"1.2.3", "2.3.9"}, []string{"1.2.2", "2.4.0"}},
+		{"1.2 - 2.3.4", []string{"1.2.0", "2.3.4"}, []string{"2.3.5"}},
+		{"^1.2 || >=3.0.0-rc.1", []string{"1.3.0", "3.0.0-rc.2", "4.0.0"}, []string{"2.0.0", "3.1.0-rc.1"}},
+	}
+
+	for _, scenario := range scenarios {
+		constraint, err := NewConstraintFromString(scenario.constraint)
+		assert.NoError(t, err)
+
+		for _, version := range scenario.satisfied {
+			tag, err := NewTagFromString(version)
+			assert.NoError(t, err)
+			assert.True(t, tag.Satisfies(constraint), "%s must satisfy %s", version, scenario.constraint)
+		}
+
+		for _, version := range scenario.unsatisfied {
+			tag, err := NewTagFromString(version)
+			assert.NoError(t, err)
+			assert.False(t, tag.Satisfies(constraint), "%s must not satisfy %s", version, scenario.constraint)
+		}
+	}
+}
+
+func TestNewConstraintFromString(t *testing.T) {
+	_, err := NewConstraintFromString(">=1.0.0 <2.a")
+	assert.EqualError(t, err, ">=1.0.0 <2.a is not a valid constraint : 2.a is not a valid version")
+
+	_, err = NewConstraintFromString("1.0.0 - whatever")
+	assert.EqualError(t, err, "1.0.0 - whatever is not a valid constraint : whatever is not a valid version")
+
+	constraint, err := NewConstraintFromString("^1.2")
+	assert.NoError(t, err)
+	assert.Equal(t, "^1.2", constraint.String())
+}
@@ -0,0 +1,95 @@
+package github
+
+import (
+	"context"
+	"fmt"
+)
+
+// Describe computes the version of a commit from the highest tag it descends from like git describe,
+// the tag is bumped according to the labels of the pull requests merged since then, the result
+// is a pre-release made of the identifier and the number of commits since the tag, the short sha of
+// the commit is added as build metadata, for instance 1.5.0-next.12+g1a2b3c4, so it precedes
+// the next release, the tag itself is returned when it points to the commit
+func (c ChangelogService) Describe(commitSha string, identifier string) (Tag, error) {
+	tags, err := c.listTags()
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var baseTag *Tag
+	var distance int
+	commitShas := []string{}
+
+	for i := len(tags) - 1; i >= 0; i-- {
+		if tags[i].sha == commitSha {
+			return tags[i].tag, nil
+		}
+
+		comparison, _, err := c.client.Repositories.CompareCommits(context.Background(), c.owner, c.repository, tags[i].tag.String(), commitSha)
+		if err != nil {
+			return Tag{}, fmt.Errorf("can't compare %s with commit %s : %s", tags[i].tag, commitSha, err)
+		}
+
+		if comparison.GetStatus() != "ahead" {
+			continue
+		}
+
+		baseTag = &tags[i].tag
+		distance = comparison.GetAheadBy()
+
+		commitShas, err = c.getComparedCommits(comparison, commitSha)
+		if err != nil {
+			return Tag{}, err
+		}
+
+		break
+	}
+
+	if baseTag == nil {
+		commitShas, err = c.getCommits(nil, commitSha)
+		if err != nil {
+			return Tag{}, err
+		}
+
+		distance = len(commitShas)
+	}
+
+	version, err := c.getHighestVersion(commitShas)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	var tag Tag
+
+	if baseTag != nil {
+		tag = c.scheme.Next(*baseTag, version)
+	} else {
+		tag = c.scheme.First(version)
+	}
+
+	tag.PreRelease = fmt.Sprintf("%s.%d", identifier, distance)
+	tag.BuildMetadata = "g" + commitSha[:7]
+
+	return tag, nil
+}
+
+// getHighestVersion returns the highest version among the pull requests
+// of commits, a patch is expected when none of them is labelled
+func (c ChangelogService) getHighestVersion(commitShas []string) (Version, error) {
+	version := PATCH
+
+	for _, sha := range commitShas {
+		pullRequests, err := c.semverLabelService.getCommitPullRequests(sha)
+		if err != nil {
+			return UNVALIDVERSION, err
+		}
+
+		for _, pr := range pullRequests {
+			if v, err := extractSemverLabels(pr.Labels); err == nil && v > version {
+				version = v
+			}
+		}
+	}
+
+	return version, nil
+}
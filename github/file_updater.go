@@ -0,0 +1,281 @@
+package github
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileUpdater kinds
+const (
+	FileUpdaterFile  = "file"
+	FileUpdaterRegex = "regex"
+	FileUpdaterJSON  = "json"
+	FileUpdaterYAML  = "yaml"
+)
+
+// FileUpdater writes a tag in place of the version stored in a repository file,
+// the version is written with a leading v only if the current one has it
+type FileUpdater struct {
+	Kind string
+	Path string
+	// Expression is a regular expression for the regex kind, its first group
+	// or the whole match is replaced, it's a dot separated key path for json and yaml kinds
+	Expression string
+}
+
+// NewFileUpdaterFromString parses a file updater defined as kind:path[:expression]
+func NewFileUpdaterFromString(updater string) (FileUpdater, error) {
+	parts := strings.SplitN(updater, ":", 3)
+
+	if len(parts) < 2 || parts[1] == "" {
+		return FileUpdater{}, fmt.Errorf("%s is not a valid file updater, use kind:path[:expression]", updater)
+	}
+
+	f := FileUpdater{Kind: parts[0], Path: parts[1]}
+
+	if len(parts) == 3 {
+		f.Expression = parts[2]
+	}
+
+	switch f.Kind {
+	case FileUpdaterFile:
+		if f.Expression != "" {
+			return FileUpdater{}, fmt.Errorf("%s is not a valid file updater, file kind has no expression", updater)
+		}
+	case FileUpdaterRegex:
+		if _, err := regexp.Compile(f.Expression); err != nil || f.Expression == "" {
+			return FileUpdater{}, fmt.Errorf("%s is not a valid file updater, regex kind requires a valid regular expression", updater)
+		}
+	case FileUpdaterJSON, FileUpdaterYAML:
+		if f.Expression == "" {
+			return FileUpdater{}, fmt.Errorf("%s is not a valid file updater, %s kind requires a key path", updater, f.Kind)
+		}
+	default:
+		return FileUpdater{}, fmt.Errorf("%s is not a valid file updater, kind must be file, regex, json or yaml", updater)
+	}
+
+	return f, nil
+}
+
+// String returns the file updater definition
+func (f FileUpdater) String() string {
+	if f.Expression == "" {
+		return fmt.Sprintf("%s:%s", f.Kind, f.Path)
+	}
+
+	return fmt.Sprintf("%s:%s:%s", f.Kind, f.Path, f.Expression)
+}
+
+// Update returns the content with its version replaced by the tag
+func (f FileUpdater) Update(content []byte, tag Tag) ([]byte, error) {
+	switch f.Kind {
+	case FileUpdaterFile:
+		return f.updateFile(content, tag)
+	case FileUpdaterRegex:
+		return f.updateRegex(content, tag)
+	case FileUpdaterJSON:
+		return f.updateJSON(content, tag)
+	case FileUpdaterYAML:
+		return f.updateYAML(content, tag)
+	}
+
+	return nil, fmt.Errorf("%s is not a valid file updater kind", f.Kind)
+}
+
+func (f FileUpdater) updateFile(content []byte, tag Tag) ([]byte, error) {
+	current := strings.TrimSpace(string(content))
+
+	return []byte(formatVersion(current, tag) + "\n"), nil
+}
+
+func (f FileUpdater) updateRegex(content []byte, tag Tag) ([]byte, error) {
+	indexes := regexp.MustCompile(f.Expression).FindSubmatchIndex(content)
+	if indexes == nil {
+		return nil, fmt.Errorf("no version matching %s found in %s", f.Expression, f.Path)
+	}
+
+	start, end := indexes[0], indexes[1]
+	if len(indexes) > 2 {
+		start, end = indexes[2], indexes[3]
+	}
+
+	return replaceRange(content, start, end, formatVersion(string(content[start:end]), tag)), nil
+}
+
+// updateJSON walks the json tokens to replace the value in place,
+// the formatting of the file is preserved
+func (f FileUpdater) updateJSON(content []byte, tag Tag) ([]byte, error) {
+	type frame struct {
+		object    bool
+		expectKey bool
+		key       string
+	}
+
+	path := strings.Split(f.Expression, ".")
+	stack := []*frame{}
+	decoder := json.NewDecoder(bytes.NewReader(content))
+
+	valueDone := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	matchPath := func() bool {
+		if len(stack) != len(path) {
+			return false
+		}
+
+		for i, fr := range stack {
+			if !fr.object || fr.key != path[i] {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	for {
+		start := int(decoder.InputOffset())
+
+		token, err := decoder.Token()
+		if err == io.EOF && len(stack) == 0 {
+			break
+		}
+
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("can't parse json file %s : %s", f.Path, err)
+		}
+
+		if delim, ok := token.(json.Delim); ok {
+			switch delim {
+			case '{':
+				stack = append(stack, &frame{object: true, expectKey: true})
+			case '[':
+				stack = append(stack, &frame{})
+			default:
+				stack = stack[:len(stack)-1]
+				valueDone()
+			}
+
+			continue
+		}
+
+		if len(stack) > 0 && stack[len(stack)-1].expectKey {
+			stack[len(stack)-1].key = token.(string)
+			stack[len(stack)-1].expectKey = false
+			continue
+		}
+
+		if matchPath() {
+			current, ok := token.(string)
+			if !ok {
+				return nil, fmt.Errorf("%s in %s is not a string", f.Expression, f.Path)
+			}
+
+			for strings.ContainsRune(" \t\r\n:,", rune(content[start])) {
+				start++
+			}
+
+			value, err := json.Marshal(formatVersion(current, tag))
+			if err != nil {
+				return nil, err
+			}
+
+			return replaceRange(content, start, int(decoder.InputOffset()), string(value)), nil
+		}
+
+		valueDone()
+	}
+
+	return nil, fmt.Errorf("no key %s found in %s", f.Expression, f.Path)
+}
+
+// updateYAML locates the scalar in the yaml tree to replace it in place,
+// the formatting of the file is preserved
+func (f FileUpdater) updateYAML(content []byte, tag Tag) ([]byte, error) {
+	document := yaml.Node{}
+	if err := yaml.Unmarshal(content, &document); err != nil {
+		return nil, fmt.Errorf("can't parse yaml file %s : %s", f.Path, err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, fmt.Errorf("no key %s found in %s", f.Expression, f.Path)
+	}
+
+	node := document.Content[0]
+
+	for _, key := range strings.Split(f.Expression, ".") {
+		var value *yaml.Node
+
+		if node.Kind == yaml.MappingNode {
+			for i := 0; i+1 < len(node.Content); i += 2 {
+				if node.Content[i].Value == key {
+					value = node.Content[i+1]
+				}
+			}
+		}
+
+		if value == nil {
+			return nil, fmt.Errorf("no key %s found in %s", f.Expression, f.Path)
+		}
+
+		node = value
+	}
+
+	if node.Kind != yaml.ScalarNode {
+		return nil, fmt.Errorf("%s in %s is not a scalar", f.Expression, f.Path)
+	}
+
+	quote := ""
+
+	switch node.Style {
+	case yaml.DoubleQuotedStyle:
+		quote = `"`
+	case yaml.SingleQuotedStyle:
+		quote = "'"
+	case 0:
+	default:
+		return nil, fmt.Errorf("%s in %s must be a plain or quoted scalar", f.Expression, f.Path)
+	}
+
+	start := 0
+	for line := 1; line < node.Line; line++ {
+		start += bytes.IndexByte(content[start:], '\n') + 1
+	}
+	start += node.Column - 1
+
+	raw := quote + node.Value + quote
+	if !bytes.HasPrefix(content[start:], []byte(raw)) {
+		return nil, fmt.Errorf("can't locate %s in %s", f.Expression, f.Path)
+	}
+
+	return replaceRange(content, start, start+len(raw), quote+formatVersion(node.Value, tag)+quote), nil
+}
+
+// formatVersion writes the bare version of a tag, without its prefix
+// nor template, keeping the leading v of the current version
+func formatVersion(current string, tag Tag) string {
+	tag.LeadingV = strings.HasPrefix(current, "v")
+	tag.Prefix = ""
+	tag.Template = ""
+
+	return tag.String()
+}
+
+func replaceRange(content []byte, start int, end int, value string) []byte {
+	b := append([]byte{}, content[:start]...)
+	b = append(b, value...)
+
+	return append(b, content[end:]...)
+}
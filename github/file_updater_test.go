@@ -0,0 +1,235 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewFileUpdaterFromString(t *testing.T) {
+	scenarios := []struct {
+		name     string
+		argument string
+		test     func(FileUpdater, error)
+	}{
+		{
+			"Parse a file updater",
+			"file:VERSION",
+			func(f FileUpdater, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, FileUpdater{Kind: FileUpdaterFile, Path: "VERSION"}, f)
+				assert.Equal(t, "file:VERSION", f.String())
+			},
+		},
+		{
+			"Parse a regex updater with colons in the expression",
+			`regex:version.go:Version = "(.+)" // default: dev`,
+			func(f FileUpdater, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, FileUpdater{Kind: FileUpdaterRegex, Path: "version.go", Expression: `Version = "(.+)" // default: dev`}, f)
+			},
+		},
+		{
+			"Parse a json updater",
+			"json:package.json:version",
+			func(f FileUpdater, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"}, f)
+				assert.Equal(t, "json:package.json:version", f.String())
+			},
+		},
+		{
+			"Parse an updater without path",
+			"json",
+			func(f FileUpdater, err error) {
+				assert.EqualError(t, err, "json is not a valid file updater, use kind:path[:expression]")
+			},
+		},
+		{
+			"Parse an updater with an unknown kind",
+			"toml:Cargo.toml:package.version",
+			func(f FileUpdater, err error) {
+				assert.EqualError(t, err, "toml:Cargo.toml:package.version is not a valid file updater, kind must be file, regex, json or yaml")
+			},
+		},
+		{
+			"Parse a file updater with an expression",
+			"file:VERSION:version",
+			func(f FileUpdater, err error) {
+				assert.EqualError(t, err, "file:VERSION:version is not a valid file updater, file kind has no expression")
+			},
+		},
+		{
+			"Parse a regex updater with an invalid regular expression",
+			"regex:version.go:(",
+			func(f FileUpdater, err error) {
+				assert.EqualError(t, err, "regex:version.go:( is not a valid file updater, regex kind requires a valid regular expression")
+			},
+		},
+		{
+			"Parse a yaml updater without key path",
+			"yaml:Chart.yaml",
+			func(f FileUpdater, err error) {
+				assert.EqualError(t, err, "yaml:Chart.yaml is not a valid file updater, yaml kind requires a key path")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(NewFileUpdaterFromString(scenario.argument))
+		})
+	}
+}
+
+func TestFileUpdaterUpdate(t *testing.T) {
+	tag := Tag{LeadingV: true, Major: 1, Minor: 2, Patch: 0}
+
+	scenarios := []struct {
+		name    string
+		updater FileUpdater
+		content string
+		test    func([]byte, error)
+	}{
+		{
+			"Update a version file",
+			FileUpdater{Kind: FileUpdaterFile, Path: "VERSION"},
+			"1.1.0\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "1.2.0\n", string(content))
+			},
+		},
+		{
+			"Update a version file keeping the leading v",
+			FileUpdater{Kind: FileUpdaterFile, Path: "VERSION"},
+			"v1.1.0",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "v1.2.0\n", string(content))
+			},
+		},
+		{
+			"Update a go constant with a regex group",
+			FileUpdater{Kind: FileUpdaterRegex, Path: "version.go", Expression: `Version = "(.+)"`},
+			"package main\n\nconst Version = \"v1.1.0\"\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "package main\n\nconst Version = \"v1.2.0\"\n", string(content))
+			},
+		},
+		{
+			"Update the first match of a regex without group",
+			FileUpdater{Kind: FileUpdaterRegex, Path: "pom.xml", Expression: `\d+\.\d+\.\d+`},
+			"<version>1.1.0</version>\n<version>3.0.0</version>\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "<version>1.2.0</version>\n<version>3.0.0</version>\n", string(content))
+			},
+		},
+		{
+			"Regex doesn't match",
+			FileUpdater{Kind: FileUpdaterRegex, Path: "version.go", Expression: `Version = "(.+)"`},
+			"package main\n",
+			func(content []byte, err error) {
+				assert.EqualError(t, err, `no version matching Version = "(.+)" found in version.go`)
+			},
+		},
+		{
+			"Update a json key preserving formatting",
+			FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"},
+			"{\n  \"name\": \"versem\",\n  \"dependencies\": {\"version\": \"0.1.0\"},\n  \"files\": [\"a\", {\"version\": \"2.0.0\"}],\n  \"version\" :  \"1.1.0\",\n  \"private\": true\n}\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "{\n  \"name\": \"versem\",\n  \"dependencies\": {\"version\": \"0.1.0\"},\n  \"files\": [\"a\", {\"version\": \"2.0.0\"}],\n  \"version\" :  \"1.2.0\",\n  \"private\": true\n}\n", string(content))
+			},
+		},
+		{
+			"Update a nested json key",
+			FileUpdater{Kind: FileUpdaterJSON, Path: "package-lock.json", Expression: "packages..version"},
+			`{"packages":{"":{"version":"1.1.0"}}}`,
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, `{"packages":{"":{"version":"1.2.0"}}}`, string(content))
+			},
+		},
+		{
+			"Json key is not found",
+			FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"},
+			`{"name":"versem"}`,
+			func(content []byte, err error) {
+				assert.EqualError(t, err, "no key version found in package.json")
+			},
+		},
+		{
+			"Json value is not a string",
+			FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"},
+			`{"version":1}`,
+			func(content []byte, err error) {
+				assert.EqualError(t, err, "version in package.json is not a string")
+			},
+		},
+		{
+			"Json file is invalid",
+			FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"},
+			`{"name":`,
+			func(content []byte, err error) {
+				assert.EqualError(t, err, "can't parse json file package.json : unexpected EOF")
+			},
+		},
+		{
+			"Update a yaml key preserving formatting",
+			FileUpdater{Kind: FileUpdaterYAML, Path: "Chart.yaml", Expression: "version"},
+			"apiVersion: v2\n# chart version\nversion: 1.1.0 # bumped by versem\nappVersion: \"v1.1.0\"\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "apiVersion: v2\n# chart version\nversion: 1.2.0 # bumped by versem\nappVersion: \"v1.1.0\"\n", string(content))
+			},
+		},
+		{
+			"Update a nested quoted yaml key",
+			FileUpdater{Kind: FileUpdaterYAML, Path: "values.yaml", Expression: "image.tag"},
+			"image:\n  repository: versem\n  tag: 'v1.1.0'\n",
+			func(content []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "image:\n  repository: versem\n  tag: 'v1.2.0'\n", string(content))
+			},
+		},
+		{
+			"Yaml key is not found",
+			FileUpdater{Kind: FileUpdaterYAML, Path: "Chart.yaml", Expression: "app.version"},
+			"app: versem\n",
+			func(content []byte, err error) {
+				assert.EqualError(t, err, "no key app.version found in Chart.yaml")
+			},
+		},
+		{
+			"Yaml value is not a scalar",
+			FileUpdater{Kind: FileUpdaterYAML, Path: "Chart.yaml", Expression: "version"},
+			"version:\n  - 1.1.0\n",
+			func(content []byte, err error) {
+				assert.EqualError(t, err, "version in Chart.yaml is not a scalar")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(scenario.updater.Update([]byte(scenario.content), tag))
+		})
+	}
+}
+
+func TestFileUpdaterUpdateWithPrefixedAndTemplatedTag(t *testing.T) {
+	tag := Tag{Prefix: "api/", Template: "release-{version}", LeadingV: true, Major: 1, Minor: 2}
+
+	content, err := FileUpdater{Kind: FileUpdaterFile, Path: "VERSION"}.Update([]byte("1.1.0\n"), tag)
+	assert.NoError(t, err)
+	assert.Equal(t, "1.2.0\n", string(content))
+
+	content, err = FileUpdater{Kind: FileUpdaterJSON, Path: "package.json", Expression: "version"}.Update([]byte(`{"version":"v1.1.0"}`), tag)
+	assert.NoError(t, err)
+	assert.Equal(t, `{"version":"v1.2.0"}`, string(content))
+}
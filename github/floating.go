@@ -0,0 +1,144 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const latestAlias = "latest"
+
+// GetFloatingTags returns the docker style aliases of a release : its major
+// line, its minor line, its full version and latest, a line alias is only
+// given when the release is the highest of the line among existing tags,
+// so a patch of an old maintenance line never moves 1 or latest backwards,
+// a pre-release only gets its full version
+func (r ReleaseService) GetFloatingTags(tag Tag) ([]string, error) {
+	if tag.Format != "" {
+		return nil, fmt.Errorf("floating tags are only computed for semver tags")
+	}
+
+	version := fmt.Sprintf("%d.%d.%d", tag.Major, tag.Minor, tag.Patch)
+
+	if tag.PreRelease != "" {
+		return []string{version + "-" + tag.PreRelease}, nil
+	}
+
+	highestMajor, highestMinor, highest := true, true, true
+	options := &github.ListOptions{Page: 1, PerPage: 100}
+
+	for {
+		tags, res, err := r.client.Repositories.ListTags(context.Background(), r.owner, r.repository, options)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch tags : %s", err)
+		}
+
+		for _, t := range tags {
+			other, err := parsePrefixedTag(t.GetName(), tag.Prefix, r.scheme)
+			if err != nil || other.PreRelease != "" || !tagLess(tag, other) {
+				continue
+			}
+
+			highest = false
+
+			if other.Major == tag.Major {
+				highestMajor = false
+
+				if other.Minor == tag.Minor {
+					highestMinor = false
+				}
+			}
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		options.Page = res.NextPage
+	}
+
+	aliases := []string{}
+
+	if highestMajor {
+		aliases = append(aliases, fmt.Sprintf("%d", tag.Major))
+	}
+
+	if highestMinor {
+		aliases = append(aliases, fmt.Sprintf("%d.%d", tag.Major, tag.Minor))
+	}
+
+	aliases = append(aliases, version)
+
+	if highest {
+		aliases = append(aliases, latestAlias)
+	}
+
+	return aliases, nil
+}
+
+// MoveFloatingTags points the git tags of the line aliases of a release to
+// its commit and returns the ones which changed, they are named like the
+// release, for instance v1 and v1.4 for v1.4.2, or api/latest for a component
+func (r ReleaseService) MoveFloatingTags(tag Tag, aliases []string) ([]string, error) {
+	commitSha, err := r.getTagCommit(tag.String())
+	if err != nil {
+		return nil, err
+	}
+
+	if commitSha == "" {
+		return nil, fmt.Errorf("tag %s doesn't exist", tag)
+	}
+
+	moved := []string{}
+
+	for _, alias := range aliases {
+		name := floatingTagName(tag, alias)
+
+		if name == tag.String() {
+			continue
+		}
+
+		sha, err := r.getTagCommit(name)
+		if err != nil {
+			return moved, err
+		}
+
+		ref := &github.Reference{Ref: github.String("refs/tags/" + name), Object: &github.GitObject{SHA: &commitSha}}
+
+		switch sha {
+		case commitSha:
+			continue
+		case "":
+			_, _, err = r.client.Git.CreateRef(context.Background(), r.owner, r.repository, ref)
+		default:
+			_, _, err = r.client.Git.UpdateRef(context.Background(), r.owner, r.repository, ref, true)
+		}
+
+		if err != nil {
+			return moved, fmt.Errorf("can't move tag %s : %s", name, err)
+		}
+
+		moved = append(moved, name)
+	}
+
+	return moved, nil
+}
+
+// floatingTagName formats an alias like the tag it belongs to
+func floatingTagName(tag Tag, alias string) string {
+	if alias == latestAlias {
+		return tag.Prefix + alias
+	}
+
+	if tag.LeadingV {
+		alias = "v" + alias
+	}
+
+	if tag.Template != "" {
+		alias = strings.Replace(tag.Template, tagTemplateVersion, alias, 1)
+	}
+
+	return tag.Prefix + alias
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const goModFilename = "go.mod"
+
+var moduleDirectiveRegexp = regexp.MustCompile(`(?m)^\s*module\s+"?([^\s"]+)"?`)
+var moduleMajorSuffixRegexp = regexp.MustCompile(`(?:^gopkg\.in/.+\.v|/v)([0-9]+)(?:-unstable)?$`)
+
+// CheckGoModule ensures the module path of the go.mod file at the root of the repository
+// matches the major version of the tag released from ref, a major release without the
+// matching /vN suffix is refused while other mismatches are returned as a warning,
+// nothing is checked when the repository has no go.mod file, the go.mod file
+// of a component tag prefixed with its directory is checked instead, calver
+// tags, templated tags and tags with a prefix which isn't a directory aren't
+// checked since they can't be go module versions
+func (r ReleaseService) CheckGoModule(version Version, tag Tag, ref string) (string, error) {
+	if tag.Format != "" || tag.Template != "" || (tag.Prefix != "" && !strings.HasSuffix(tag.Prefix, "/")) {
+		return "", nil
+	}
+
+	filename := tag.Prefix + goModFilename
+
+	content, exists, err := r.getFileContent(filename, ref)
+	if err != nil || !exists {
+		return "", err
+	}
+
+	matches := moduleDirectiveRegexp.FindStringSubmatch(content)
+	if matches == nil {
+		return "", fmt.Errorf("no module directive found in %s", filename)
+	}
+
+	modulePath := matches[1]
+	suffix := getModuleMajorSuffix(modulePath)
+
+	expected := 0
+	if tag.Major >= 2 {
+		expected = tag.Major
+	}
+
+	switch {
+	case suffix == expected:
+		return "", nil
+	case version == MAJOR && expected != 0:
+		return "", fmt.Errorf("module path %s in %s must end with /v%d to release %s", modulePath, filename, expected, tag)
+	case expected == 0:
+		return fmt.Sprintf("module path %s in %s has a /v%d suffix while tag %s is a v%d version", modulePath, filename, suffix, tag, tag.Major), nil
+	}
+
+	return fmt.Sprintf("module path %s in %s doesn't match tag %s, it should end with /v%d", modulePath, filename, tag, expected), nil
+}
+
+// getModuleMajorSuffix returns the major version carried by a module path,
+// 0 when the path has no suffix or a v0 or v1 gopkg.in suffix
+func getModuleMajorSuffix(modulePath string) int {
+	matches := moduleMajorSuffixRegexp.FindStringSubmatch(modulePath)
+	if matches == nil {
+		return 0
+	}
+
+	major, err := strconv.Atoi(matches[1])
+	if err != nil || major < 2 {
+		return 0
+	}
+
+	return major
+}
@@ -3,27 +3,50 @@ package github
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"regexp"
 	"strconv"
+	"strings"
+	"text/template"
+	"time"
 
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
 )
 
-// Tag represents a semver tag
+const createTagMaxAttempts = 3
+
+// numericIdentifier and preReleaseIdentifier match the parts of a
+// semver version, numbers can't have leading zeros
+const numericIdentifier = `0|[1-9][0-9]*`
+const preReleaseIdentifier = `(?:` + numericIdentifier + `|[0-9]*[A-Za-z-][0-9A-Za-z-]*)`
+
+// PreReleaseIdentifierRegexp matches a single pre-release identifier
+var PreReleaseIdentifierRegexp = regexp.MustCompile(`^` + preReleaseIdentifier + `$`)
+
+// Tag represents a semver tag, optionally prefixed
+// to scope it to a component, a calver tag stores its
+// segments in Major, Minor and Patch and keeps its format
 type Tag struct {
+	Prefix        string
 	LeadingV      bool
 	Major         int
 	Minor         int
 	Patch         int
 	PreRelease    string
 	BuildMetadata string
+	Format        string
+	Template      string
 }
 
 // String converts a Tag to its string representation
 func (t Tag) String() string {
 	main := fmt.Sprintf("%d.%d.%d", t.Major, t.Minor, t.Patch)
 
+	if t.Format != "" {
+		main = formatCalVer(t)
+	}
+
 	if t.LeadingV {
 		main = "v" + main
 	}
@@ -36,7 +59,11 @@ func (t Tag) String() string {
 		main = fmt.Sprintf("%s+%s", main, t.BuildMetadata)
 	}
 
-	return main
+	if t.Template != "" {
+		main = strings.Replace(t.Template, tagTemplateVersion, main, 1)
+	}
+
+	return t.Prefix + main
 }
 
 // NewTagFromString creates a Tag instance from a tag string representation
@@ -44,14 +71,106 @@ func NewTagFromString(tag string) (Tag, error) {
 	return parseStringTag(tag)
 }
 
+// NewPrefixedTagFromString creates a Tag instance of a scheme from a tag string
+// representation which can be prefixed by a component prefix ending with /, - or _
+func NewPrefixedTagFromString(tag string, scheme Scheme) (Tag, error) {
+	for i := 0; i < len(tag); i++ {
+		if i > 0 && !strings.ContainsRune("/-_", rune(tag[i-1])) {
+			continue
+		}
+
+		if t, err := parsePrefixedTag(tag, tag[:i], scheme); err == nil {
+			return t, nil
+		}
+	}
+
+	_, err := scheme.Parse(tag)
+
+	return Tag{}, err
+}
+
+// ReleaseOptions customizes the release created
+type ReleaseOptions struct {
+	// Draft creates the release as a draft, it must be published afterward
+	Draft bool
+	// TagOnly creates the git tag without any github release
+	TagOnly bool
+	// Annotated creates an annotated tag instead of a lightweight one
+	Annotated bool
+	// TagMessage renders the annotated tag message from a TagMessageData,
+	// the tag name is used when it's nil
+	TagMessage *template.Template
+	// PullRequest is the pull request released, given to the tag message template
+	PullRequest *PullRequest
+	// Tagger identifies the author of annotated tags
+	Tagger *Tagger
+	// Signer signs annotated tags, a tagger must be defined
+	Signer Signer
+	// FileUpdaters write the tag in repository files, the change is committed
+	// on top of the target commit and the tag is created on this new commit
+	FileUpdaters []FileUpdater
+	// Branch receives the bump commit, the default branch is used when empty
+	Branch string
+	// Changelog is prepended to the changelog file of the repository
+	// in the bump commit and used as release body
+	Changelog *Changelog
+	// Component scopes the release to the tags of a component
+	Component *Component
+	// BuildMetadata is appended to the release name, or to an
+	// additional tag on the same commit when BuildMetadataTag is set
+	BuildMetadata    string
+	BuildMetadataTag bool
+}
+
+// Tagger identifies the author of an annotated tag
+type Tagger struct {
+	Name  string
+	Email string
+}
+
+// TagMessageData is given to the annotated tag message template
+type TagMessageData struct {
+	Tag         Tag
+	Version     string
+	PullRequest PullRequest
+	Changelog   string
+}
+
+// TagExistsError is returned when the computed tag
+// already exists and points to another commit
+type TagExistsError struct {
+	Tag       Tag
+	CommitSha string
+}
+
+// Error returns the error message
+func (e TagExistsError) Error() string {
+	return fmt.Sprintf("tag %s already exists and points to commit %s", e.Tag, e.CommitSha)
+}
+
+// ReleaseStatus is the state of the github release of a tag
+type ReleaseStatus int
+
+const (
+	// NOTRELEASED means the tag doesn't have any github release
+	NOTRELEASED ReleaseStatus = iota
+	// DRAFTED means the github release of the tag is a draft
+	DRAFTED
+	// PUBLISHED means the github release of the tag is published
+	PUBLISHED
+)
+
 // ReleaseService deals with git Releases
 type ReleaseService struct {
 	owner      string
 	repository string
 	client     *github.Client
+	now        func() time.Time
+	scheme     Scheme
 }
 
 // NewReleaseService creates a new instance of ReleaseService
+// computing semver tags
 func NewReleaseService(owner string, repository string, token string) ReleaseService {
 	return ReleaseService{
 		owner,
@@ -62,45 +181,536 @@ func NewReleaseService(owner string, repository string, token string) ReleaseSer
 				oauth2.StaticTokenSource(&oauth2.Token{AccessToken: token}),
 			),
 		),
+		time.Now,
+		SemverScheme{},
 	}
 }
 
-// CreateNext release from the given version and the last semver tag at target commitish
-func (r ReleaseService) CreateNext(version Version, targetCommitish string) (Tag, error) {
-	tags, _, err := r.client.Repositories.ListTags(context.Background(), r.owner, r.repository, &github.ListOptions{Page: 1, PerPage: 1})
+// WithScheme returns a copy of the service computing tags with a scheme
+func (r ReleaseService) WithScheme(scheme Scheme) ReleaseService {
+	r.scheme = scheme
+	return r
+}
+
+// GetNext computes the next tag from the given version and the highest tag,
+// tags which can't be parsed like floating aliases are skipped,
+// the last tag is nil when the repository doesn't have any tag yet,
+// when a component is given only its prefixed tags are considered
+func (r ReleaseService) GetNext(version Version, component *Component) (*Tag, Tag, error) {
+	prefix := ""
+	if component != nil {
+		prefix = component.Prefix
+	}
+
+	lastTag, nextTag, _, err := r.getNext(version, prefix, "")
+	return lastTag, nextTag, err
+}
+
+// getNext computes the last and the next tags starting with a prefix, it returns
+// as well the tag pointing to a commit, nil when there is none
+func (r ReleaseService) getNext(version Version, prefix string, commitSha string) (*Tag, Tag, *Tag, error) {
+	var lastTag *Tag
+	var commitTag *Tag
+
+	options := &github.ListOptions{Page: 1, PerPage: 100}
+
+	for {
+		tags, res, err := r.client.Repositories.ListTags(context.Background(), r.owner, r.repository, options)
+		if err != nil {
+			return nil, Tag{}, nil, fmt.Errorf("can't fetch tags : %s", err)
+		}
+
+		for _, t := range tags {
+			tag, err := parsePrefixedTag(t.GetName(), prefix, r.scheme)
+			if err != nil {
+				continue
+			}
+
+			if commitTag == nil && commitSha != "" && t.GetCommit().GetSHA() == commitSha {
+				commitTag = &tag
+			}
+
+			if lastTag == nil || tagLess(*lastTag, tag) {
+				lastTag = &tag
+			}
+		}
+
+		if res.NextPage == 0 {
+			break
+		}
+
+		options.Page = res.NextPage
+	}
+
+	if lastTag == nil {
+		firstTag := r.scheme.First(version)
+		firstTag.Prefix = prefix
+
+		return nil, firstTag, nil, nil
+	}
+
+	return lastTag, r.scheme.Next(*lastTag, version), commitTag, nil
+}
+
+// GetFromCommit finds out the semver tag or release attached to a commit,
+// it returns nil when the commit doesn't carry any, when a component
+// is given only its prefixed tags are considered
+func (r ReleaseService) GetFromCommit(commitSha string, component *Component) (*Tag, error) {
+	prefix := ""
+	if component != nil {
+		prefix = component.Prefix
+	}
+
+	_, _, commitTag, err := r.getNext(NORELEASE, prefix, commitSha)
+	if err != nil || commitTag != nil {
+		return commitTag, err
+	}
+
+	options := &github.ListOptions{Page: 1, PerPage: 100}
+
+	for {
+		releases, res, err := r.client.Repositories.ListReleases(context.Background(), r.owner, r.repository, options)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch releases : %s", err)
+		}
+
+		for _, release := range releases {
+			if release.GetTargetCommitish() != commitSha {
+				continue
+			}
+
+			if tag, err := parsePrefixedTag(release.GetTagName(), prefix, r.scheme); err == nil {
+				return &tag, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+
+		options.Page = res.NextPage
+	}
+}
+
+// CreateNext release from the given version and the last semver tag at target commitish,
+// when a tag already points to the target commitish, like after a failed run, it's reused
+// and only the missing release is created
+func (r ReleaseService) CreateNext(version Version, targetCommitish string, options ReleaseOptions) (Tag, error) {
+	var bumpedTag *Tag
+
+	if len(options.FileUpdaters) > 0 || options.Changelog != nil {
+		_, tag, err := r.GetNext(version, options.Component)
+		if err != nil {
+			return Tag{}, err
+		}
+
+		if options.Changelog != nil && options.Changelog.Tag != tag {
+			return Tag{}, fmt.Errorf("changelog was built for %s while next tag is %s", options.Changelog.Tag, tag)
+		}
+
+		targetCommitish, err = r.bumpFiles(tag, targetCommitish, options.Branch, options.FileUpdaters, options.Changelog)
+		if err != nil {
+			return Tag{}, err
+		}
+
+		bumpedTag = &tag
+	}
+
+	nextTag, tagExists, err := r.reserveTag(version, targetCommitish, bumpedTag, options)
 	if err != nil {
-		return Tag{}, fmt.Errorf("can't fetch latest tag : %s", err)
+		return Tag{}, err
 	}
 
-	lastTag := Tag{LeadingV: true}
+	buildTag := nextTag
+	buildTag.BuildMetadata = options.BuildMetadata
 
-	if len(tags) > 0 {
-		lastTag, err = NewTagFromString(tags[0].GetName())
+	if options.BuildMetadata != "" && options.BuildMetadataTag {
+		if err := r.createTagRef(buildTag, targetCommitish, version, options); err != nil && !isReferenceConflict(err) {
+			return Tag{}, fmt.Errorf("can't create tag %s : %s", buildTag, err)
+		}
+	}
+
+	if options.TagOnly {
+		return nextTag, nil
+	}
+
+	if tagExists {
+		release, err := r.findRelease(nextTag)
 		if err != nil {
-			return Tag{}, fmt.Errorf("can't parse tag : %s", err)
+			return Tag{}, err
+		}
+
+		if release != nil {
+			return nextTag, nil
 		}
 	}
 
-	nextTag := getNextTag(lastTag, version)
 	tagStr := nextTag.String()
+	release := &github.RepositoryRelease{
+		TagName:         &tagStr,
+		TargetCommitish: &targetCommitish,
+	}
+
+	if options.Draft {
+		release.Draft = &options.Draft
+	}
+
+	if options.BuildMetadata != "" && !options.BuildMetadataTag {
+		name := buildTag.String()
+		release.Name = &name
+	}
+
+	if options.Changelog != nil {
+		body := options.Changelog.Body()
+		release.Body = &body
+	}
 
-	if _, _, err = r.client.Repositories.CreateRelease(
-		context.Background(),
-		r.owner,
-		r.repository,
-		&github.RepositoryRelease{
-			TagName:         &tagStr,
-			TargetCommitish: &targetCommitish,
-		},
-	); err != nil {
+	if _, _, err := r.client.Repositories.CreateRelease(context.Background(), r.owner, r.repository, release); err != nil {
 		return Tag{}, fmt.Errorf("can't create release : %s", err)
 	}
 
 	return nextTag, nil
 }
 
+// Init creates the first tag of a repository on the target commitish, it's
+// the initial version of the scheme and it's refused once a tag exists
+func (r ReleaseService) Init(targetCommitish string, options ReleaseOptions) (Tag, error) {
+	lastTag, tag, err := r.GetNext(NORELEASE, options.Component)
+	if err != nil {
+		return Tag{}, err
+	}
+
+	if lastTag != nil {
+		return Tag{}, fmt.Errorf("tag %s already exists, nothing to initialize", lastTag)
+	}
+
+	if err := r.createTagRef(tag, targetCommitish, NORELEASE, options); err != nil {
+		return Tag{}, fmt.Errorf("can't create tag %s : %s", tag, err)
+	}
+
+	return tag, nil
+}
+
+// Publish turns the draft release of a tag into a published release
+func (r ReleaseService) Publish(tag Tag) error {
+	release, err := r.findRelease(tag)
+	if err != nil {
+		return err
+	}
+
+	if release == nil {
+		return fmt.Errorf("no release found for tag %s", tag)
+	}
+
+	draft := false
+
+	if _, _, err := r.client.Repositories.EditRelease(context.Background(), r.owner, r.repository, release.GetID(), &github.RepositoryRelease{Draft: &draft}); err != nil {
+		return fmt.Errorf("can't publish release %s : %s", tag, err)
+	}
+
+	return nil
+}
+
+// Discard deletes the draft release of a tag and the tag itself,
+// a tag without draft release is refused since it may be a published one
+func (r ReleaseService) Discard(tag Tag) error {
+	release, err := r.findRelease(tag)
+	if err != nil {
+		return err
+	}
+
+	if release == nil {
+		return fmt.Errorf("no draft release found for %s", tag)
+	}
+
+	if !release.GetDraft() {
+		return fmt.Errorf("release %s is published, only a draft release can be discarded", tag)
+	}
+
+	if _, err := r.client.Repositories.DeleteRelease(context.Background(), r.owner, r.repository, release.GetID()); err != nil {
+		return fmt.Errorf("can't delete release %s : %s", tag, err)
+	}
+
+	res, err := r.client.Git.DeleteRef(context.Background(), r.owner, r.repository, "tags/"+tag.String())
+
+	switch {
+	case err == nil:
+		return nil
+	case res != nil && res.StatusCode == http.StatusUnprocessableEntity:
+		// the tag of a draft release doesn't always exist
+		return nil
+	}
+
+	return fmt.Errorf("can't delete tag %s : %s", tag, err)
+}
+
+// GetReleaseStatus tells whether a tag has a github release and if it's a draft
+func (r ReleaseService) GetReleaseStatus(tag Tag) (ReleaseStatus, error) {
+	release, err := r.findRelease(tag)
+
+	switch {
+	case err != nil:
+		return NOTRELEASED, err
+	case release == nil:
+		return NOTRELEASED, nil
+	case release.GetDraft():
+		return DRAFTED, nil
+	}
+
+	return PUBLISHED, nil
+}
+
+// findRelease looks for the release of a tag among all releases
+// since draft releases can't be fetched from their tag,
+// it returns nil when there is none
+func (r ReleaseService) findRelease(tag Tag) (*github.RepositoryRelease, error) {
+	options := &github.ListOptions{Page: 1, PerPage: 100}
+
+	for {
+		releases, res, err := r.client.Repositories.ListReleases(context.Background(), r.owner, r.repository, options)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch releases : %s", err)
+		}
+
+		for _, release := range releases {
+			if release.GetTagName() == tag.String() {
+				return release, nil
+			}
+		}
+
+		if res.NextPage == 0 {
+			return nil, nil
+		}
+
+		options.Page = res.NextPage
+	}
+}
+
+// reserveTag computes the next tag and creates it on the target commitish,
+// when the tag is taken by a concurrent release, tags are fetched again to compute a new one,
+// a tag already pointing to the target commitish is reused and the returned boolean is true,
+// when files were bumped, the tag must stay the one written in files
+func (r ReleaseService) reserveTag(version Version, targetCommitish string, bumpedTag *Tag, options ReleaseOptions) (Tag, bool, error) {
+	var previousTag *Tag
+
+	prefix := ""
+	if options.Component != nil {
+		prefix = options.Component.Prefix
+	}
+
+	for attempt := 1; attempt <= createTagMaxAttempts; attempt++ {
+		_, tag, commitTag, err := r.getNext(version, prefix, targetCommitish)
+		if err != nil {
+			return Tag{}, false, err
+		}
+
+		if commitTag != nil {
+			return *commitTag, true, nil
+		}
+
+		if bumpedTag != nil && *bumpedTag != tag {
+			return Tag{}, false, fmt.Errorf("next tag is now %s while files were bumped to %s on commit %s", tag, *bumpedTag, targetCommitish)
+		}
+
+		commitSha, err := r.getTagCommit(tag.String())
+		if err != nil {
+			return Tag{}, false, err
+		}
+
+		switch {
+		case commitSha == targetCommitish:
+			return tag, true, nil
+		case commitSha != "" && previousTag != nil && *previousTag == tag:
+			return Tag{}, false, TagExistsError{tag, commitSha}
+		case commitSha == "":
+			err := r.createTagRef(tag, targetCommitish, version, options)
+			if err == nil {
+				return tag, false, nil
+			}
+
+			if !isReferenceConflict(err) {
+				return Tag{}, false, fmt.Errorf("can't create tag %s : %s", tag, err)
+			}
+		}
+
+		previousTag = &tag
+	}
+
+	return Tag{}, false, fmt.Errorf("can't create tag %s : tag still exists after %d attempts", *previousTag, createTagMaxAttempts)
+}
+
+// getTagCommit returns the commit sha a tag points to,
+// or an empty string when the tag doesn't exist
+func (r ReleaseService) getTagCommit(tag string) (string, error) {
+	ref, res, err := r.client.Git.GetRef(context.Background(), r.owner, r.repository, "tags/"+tag)
+	if err != nil {
+		// a successful response that can't be decoded as a single reference means
+		// github only found tags starting with this name
+		if res != nil && (res.StatusCode == http.StatusNotFound || res.StatusCode == http.StatusOK) {
+			return "", nil
+		}
+		return "", fmt.Errorf("can't fetch tag %s : %s", tag, err)
+	}
+
+	if ref.GetObject().GetType() != "tag" {
+		return ref.GetObject().GetSHA(), nil
+	}
+
+	annotatedTag, _, err := r.client.Git.GetTag(context.Background(), r.owner, r.repository, ref.GetObject().GetSHA())
+	if err != nil {
+		return "", fmt.Errorf("can't fetch tag %s : %s", tag, err)
+	}
+
+	return annotatedTag.GetObject().GetSHA(), nil
+}
+
+func (r ReleaseService) createTagRef(tag Tag, commitSha string, version Version, options ReleaseOptions) error {
+	ref := "refs/tags/" + tag.String()
+	sha := commitSha
+
+	if options.Annotated {
+		var err error
+
+		sha, err = r.createAnnotatedTag(tag, commitSha, version, options)
+		if err != nil {
+			return err
+		}
+	}
+
+	_, _, err := r.client.Git.CreateRef(context.Background(), r.owner, r.repository, &github.Reference{
+		Ref:    &ref,
+		Object: &github.GitObject{SHA: &sha},
+	})
+
+	return err
+}
+
+// createAnnotatedTag creates the tag object pointing to the commit and returns its sha,
+// when the tag is signed, the signature is computed from the tag object
+// as git would store it and appended to the message
+func (r ReleaseService) createAnnotatedTag(tag Tag, commitSha string, version Version, options ReleaseOptions) (string, error) {
+	name := tag.String()
+	objectType := "commit"
+	message := name
+
+	if options.TagMessage != nil {
+		data := TagMessageData{Tag: tag, Version: strings.ToLower(version.String())}
+		if options.PullRequest != nil {
+			data.PullRequest = *options.PullRequest
+		}
+		if options.Changelog != nil {
+			data.Changelog = options.Changelog.Body()
+		}
+
+		b := strings.Builder{}
+		if err := options.TagMessage.Execute(&b, data); err != nil {
+			return "", fmt.Errorf("can't render tag message : %s", err)
+		}
+		message = b.String()
+	}
+
+	annotatedTag := &github.Tag{
+		Tag:    &name,
+		Object: &github.GitObject{SHA: &commitSha, Type: &objectType},
+	}
+
+	if options.Tagger != nil {
+		date := r.now().UTC().Truncate(time.Second)
+		annotatedTag.Tagger = &github.CommitAuthor{Name: &options.Tagger.Name, Email: &options.Tagger.Email, Date: &date}
+	}
+
+	if options.Signer != nil {
+		if options.Tagger == nil {
+			return "", fmt.Errorf("a tagger must be defined to sign tag %s", name)
+		}
+
+		if !strings.HasSuffix(message, "\n") {
+			message += "\n"
+		}
+
+		payload := fmt.Sprintf(
+			"object %s\ntype %s\ntag %s\ntagger %s <%s> %d +0000\n\n%s",
+			commitSha,
+			objectType,
+			name,
+			options.Tagger.Name,
+			options.Tagger.Email,
+			annotatedTag.Tagger.GetDate().Unix(),
+			message,
+		)
+
+		signature, err := options.Signer.Sign([]byte(payload))
+		if err != nil {
+			return "", err
+		}
+
+		message += string(signature)
+	}
+
+	annotatedTag.Message = &message
+
+	t, _, err := r.client.Git.CreateTag(context.Background(), r.owner, r.repository, annotatedTag)
+	if err != nil {
+		return "", err
+	}
+
+	return t.GetSHA(), nil
+}
+
+// isReferenceConflict reports whether a reference creation failed because it already exists,
+// github answers 422 for other validation errors like an unknown sha too
+func isReferenceConflict(err error) bool {
+	e, ok := err.(*github.ErrorResponse)
+	return ok && e.Response != nil && e.Response.StatusCode == http.StatusUnprocessableEntity && e.Message == "Reference already exists"
+}
+
+// tagLess reports whether a tag has a lower precedence than another one
+// following semver rules, build metadata is ignored
+func tagLess(a Tag, b Tag) bool {
+	for _, c := range [][2]int{{a.Major, b.Major}, {a.Minor, b.Minor}, {a.Patch, b.Patch}} {
+		if c[0] != c[1] {
+			return c[0] < c[1]
+		}
+	}
+
+	switch {
+	case a.PreRelease == b.PreRelease:
+		return false
+	case a.PreRelease == "":
+		return false
+	case b.PreRelease == "":
+		return true
+	}
+
+	aIDs := strings.Split(a.PreRelease, ".")
+	bIDs := strings.Split(b.PreRelease, ".")
+
+	for i := 0; i < len(aIDs) && i < len(bIDs); i++ {
+		if aIDs[i] == bIDs[i] {
+			continue
+		}
+
+		aNum, aErr := strconv.Atoi(aIDs[i])
+		bNum, bErr := strconv.Atoi(bIDs[i])
+
+		switch {
+		case aErr == nil && bErr == nil:
+			return aNum < bNum
+		case aErr == nil:
+			return true
+		case bErr == nil:
+			return false
+		}
+
+		return aIDs[i] < bIDs[i]
+	}
+
+	return len(aIDs) < len(bIDs)
+}
+
 func getNextTag(previousTag Tag, version Version) Tag {
 	nextTag := Tag{
+		Prefix:   previousTag.Prefix,
 		LeadingV: previousTag.LeadingV,
 		Major:    previousTag.Major,
 		Minor:    previousTag.Minor,
@@ -122,8 +732,24 @@ func getNextTag(previousTag Tag, version Version) Tag {
 	return nextTag
 }
 
+// parsePrefixedTag parses a tag of a scheme starting with a prefix
+func parsePrefixedTag(tag string, prefix string, scheme Scheme) (Tag, error) {
+	if !strings.HasPrefix(tag, prefix) {
+		return Tag{}, fmt.Errorf("%s doesn't start with %s", tag, prefix)
+	}
+
+	t, err := scheme.Parse(strings.TrimPrefix(tag, prefix))
+	if err != nil {
+		return Tag{}, err
+	}
+
+	t.Prefix = prefix
+
+	return t, nil
+}
+
 func parseStringTag(tag string) (Tag, error) {
-	semverRe := regexp.MustCompile(`^(v?)(\d+)\.(\d+)\.(\d+)((?:\-[0-9A-Za-z]+(?:\.[0-9A-Za-z]+)*)?)((?:\+[0-9A-Za-z]+(?:\.[0-9A-Za-z]+)*)?)$`)
+	semverRe := regexp.MustCompile(`^(v?)(` + numericIdentifier + `)\.(` + numericIdentifier + `)\.(` + numericIdentifier + `)((?:\-` + preReleaseIdentifier + `(?:\.` + preReleaseIdentifier + `)*)?)((?:\+[0-9A-Za-z-]+(?:\.[0-9A-Za-z-]+)*)?)$`)
 
 	if !semverRe.MatchString(tag) {
 		return Tag{}, fmt.Errorf("%s is not a valid semver tag", tag)
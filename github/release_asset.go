@@ -0,0 +1,87 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"fmt"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const checksumsFilename = "SHA256SUMS"
+
+// UploadAssets uploads files to the release of a tag
+// with a SHA256SUMS file listing their checksums,
+// an asset already uploaded with the same name is replaced
+func (r ReleaseService) UploadAssets(tag Tag, paths []string) error {
+	release, err := r.findRelease(tag)
+	if err != nil {
+		return err
+	}
+
+	if release == nil {
+		return fmt.Errorf("no release found for tag %s", tag)
+	}
+
+	checksums := strings.Builder{}
+
+	for _, path := range paths {
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("can't read asset %s : %s", path, err)
+		}
+
+		name := filepath.Base(path)
+
+		if err := r.uploadAsset(release, name, content); err != nil {
+			return err
+		}
+
+		checksums.WriteString(fmt.Sprintf("%x  %s\n", sha256.Sum256(content), name))
+	}
+
+	return r.uploadAsset(release, checksumsFilename, []byte(checksums.String()))
+}
+
+func (r ReleaseService) uploadAsset(release *github.RepositoryRelease, name string, content []byte) error {
+	for _, asset := range release.Assets {
+		if asset.GetName() != name {
+			continue
+		}
+
+		if _, err := r.client.Repositories.DeleteReleaseAsset(context.Background(), r.owner, r.repository, asset.GetID()); err != nil {
+			return fmt.Errorf("can't delete previous asset %s : %s", name, err)
+		}
+	}
+
+	req, err := r.client.NewUploadRequest(
+		fmt.Sprintf("repos/%s/%s/releases/%d/assets?name=%s", r.owner, r.repository, release.GetID(), url.QueryEscape(name)),
+		bytes.NewReader(content),
+		int64(len(content)),
+		detectContentType(name, content),
+	)
+	if err != nil {
+		return fmt.Errorf("can't create upload request for asset %s : %s", name, err)
+	}
+
+	if _, err := r.client.Do(context.Background(), req, nil); err != nil {
+		return fmt.Errorf("can't upload asset %s : %s", name, err)
+	}
+
+	return nil
+}
+
+func detectContentType(name string, content []byte) string {
+	if contentType := mime.TypeByExtension(filepath.Ext(name)); contentType != "" {
+		return contentType
+	}
+
+	return http.DetectContentType(content)
+}
@@ -0,0 +1,214 @@
+package github
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/google/go-github/github"
+)
+
+const bumpCommitMessage = "Bump version to %s"
+
+// bumpFiles commits the files updated with the tag and the changelog on top of
+// the commit and fast forwards the branch to this new commit, the bump commit of
+// a previous run for the same tag is reused
+func (r ReleaseService) bumpFiles(tag Tag, commitSha string, branch string, updaters []FileUpdater, changelog *Changelog) (string, error) {
+	branch, head, err := r.getBranchHead(branch)
+	if err != nil {
+		return "", err
+	}
+
+	message := fmt.Sprintf(bumpCommitMessage, tag)
+
+	if head != commitSha {
+		headCommit, _, err := r.client.Git.GetCommit(context.Background(), r.owner, r.repository, head)
+		if err != nil {
+			return "", fmt.Errorf("can't fetch commit %s : %s", head, err)
+		}
+
+		if headCommit.GetMessage() == message && len(headCommit.Parents) == 1 && headCommit.Parents[0].GetSHA() == commitSha {
+			return head, nil
+		}
+
+		return "", fmt.Errorf("branch %s is at commit %s, can't bump files on top of commit %s", branch, head, commitSha)
+	}
+
+	entries := []github.TreeEntry{}
+	addEntry := func(path string, content string) {
+		entryType := "blob"
+
+		entries = append(entries, github.TreeEntry{Path: &path, Type: &entryType, Content: &content})
+	}
+
+	for _, updater := range updaters {
+		content, exists, err := r.getFileContent(updater.Path, commitSha)
+		if err != nil {
+			return "", err
+		}
+
+		if !exists {
+			return "", fmt.Errorf("file %s doesn't exist", updater.Path)
+		}
+
+		updated, err := updater.Update([]byte(content), tag)
+		if err != nil {
+			return "", err
+		}
+
+		if string(updated) != content {
+			addEntry(updater.Path, string(updated))
+		}
+	}
+
+	if changelog != nil {
+		content, _, err := r.getFileContent(ChangelogFilename, commitSha)
+		if err != nil {
+			return "", err
+		}
+
+		if updated := changelog.Prepend(content); updated != content {
+			addEntry(ChangelogFilename, updated)
+		}
+	}
+
+	if len(entries) == 0 {
+		return commitSha, nil
+	}
+
+	commit, _, err := r.client.Git.GetCommit(context.Background(), r.owner, r.repository, commitSha)
+	if err != nil {
+		return "", fmt.Errorf("can't fetch commit %s : %s", commitSha, err)
+	}
+
+	for i := range entries {
+		mode, err := r.getFileMode(commit.GetTree().GetSHA(), entries[i].GetPath())
+		if err != nil {
+			return "", err
+		}
+
+		entries[i].Mode = &mode
+	}
+
+	tree, _, err := r.client.Git.CreateTree(context.Background(), r.owner, r.repository, commit.GetTree().GetSHA(), entries)
+	if err != nil {
+		return "", fmt.Errorf("can't create tree : %s", err)
+	}
+
+	bumpCommit, _, err := r.client.Git.CreateCommit(context.Background(), r.owner, r.repository, &github.Commit{
+		Message: &message,
+		Tree:    tree,
+		Parents: []github.Commit{{SHA: &commitSha}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("can't create commit : %s", err)
+	}
+
+	branchRef := "refs/heads/" + branch
+	bumpSha := bumpCommit.GetSHA()
+
+	if _, _, err := r.client.Git.UpdateRef(context.Background(), r.owner, r.repository, &github.Reference{
+		Ref:    &branchRef,
+		Object: &github.GitObject{SHA: &bumpSha},
+	}, false); err != nil {
+		return "", fmt.Errorf("can't update branch %s : %s", branch, err)
+	}
+
+	return bumpSha, nil
+}
+
+// GetBumpCommit returns the bump commit created on top of a commit by a previous
+// release, it's the head of the branch when its only parent is the commit and
+// it carries a bump message, an empty string is returned when there is none
+func (r ReleaseService) GetBumpCommit(commitSha string, branch string) (string, error) {
+	_, head, err := r.getBranchHead(branch)
+	if err != nil || head == commitSha {
+		return "", err
+	}
+
+	headCommit, _, err := r.client.Git.GetCommit(context.Background(), r.owner, r.repository, head)
+	if err != nil {
+		return "", fmt.Errorf("can't fetch commit %s : %s", head, err)
+	}
+
+	if strings.HasPrefix(headCommit.GetMessage(), strings.TrimSuffix(bumpCommitMessage, "%s")) && len(headCommit.Parents) == 1 && headCommit.Parents[0].GetSHA() == commitSha {
+		return head, nil
+	}
+
+	return "", nil
+}
+
+// getBranchHead returns the name of a branch, the default branch
+// of the repository when it's empty, and the commit it points to
+func (r ReleaseService) getBranchHead(branch string) (string, string, error) {
+	if branch == "" {
+		repository, _, err := r.client.Repositories.Get(context.Background(), r.owner, r.repository)
+		if err != nil {
+			return "", "", fmt.Errorf("can't fetch repository : %s", err)
+		}
+
+		branch = repository.GetDefaultBranch()
+	}
+
+	ref, _, err := r.client.Git.GetRef(context.Background(), r.owner, r.repository, "heads/"+branch)
+	if err != nil {
+		return "", "", fmt.Errorf("can't fetch branch %s : %s", branch, err)
+	}
+
+	return branch, ref.GetObject().GetSHA(), nil
+}
+
+// getFileMode returns the mode of a file in a tree walking down its directories,
+// a regular file mode is returned when the file doesn't exist yet
+func (r ReleaseService) getFileMode(treeSha string, path string) (string, error) {
+	segments := strings.Split(path, "/")
+
+	for i, segment := range segments {
+		tree, _, err := r.client.Git.GetTree(context.Background(), r.owner, r.repository, treeSha, false)
+		if err != nil {
+			return "", fmt.Errorf("can't fetch tree of file %s : %s", path, err)
+		}
+
+		treeSha = ""
+
+		for _, entry := range tree.Entries {
+			if entry.GetPath() != segment {
+				continue
+			}
+
+			if i == len(segments)-1 {
+				return entry.GetMode(), nil
+			}
+
+			if entry.GetType() == "tree" {
+				treeSha = entry.GetSHA()
+			}
+		}
+
+		if treeSha == "" {
+			break
+		}
+	}
+
+	return "100644", nil
+}
+
+// getFileContent returns the content of a file at a ref
+// and whether the file exists
+func (r ReleaseService) getFileContent(path string, ref string) (string, bool, error) {
+	file, _, res, err := r.client.Repositories.GetContents(context.Background(), r.owner, r.repository, path, &github.RepositoryContentGetOptions{Ref: ref})
+	if err != nil {
+		if res != nil && res.StatusCode == http.StatusNotFound {
+			return "", false, nil
+		}
+		return "", false, fmt.Errorf("can't fetch file %s : %s", path, err)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return "", false, fmt.Errorf("can't decode file %s : %s", path, err)
+	}
+
+	return content, true, nil
+}
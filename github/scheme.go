@@ -0,0 +1,120 @@
+package github
+
+import (
+	"fmt"
+	"strings"
+)
+
+const tagTemplateVersion = "{version}"
+
+// Scheme defines how the tags of a repository are parsed and computed
+type Scheme interface {
+	// First computes the first tag of a repository according to a version
+	First(version Version) Tag
+	// Parse creates a Tag instance from a tag string representation without prefix
+	Parse(tag string) (Tag, error)
+	// Next computes the tag following a tag according to a version
+	Next(previousTag Tag, version Version) Tag
+}
+
+// NewSchemeFromString creates a scheme defined as semver or calver:format
+func NewSchemeFromString(scheme string) (Scheme, error) {
+	switch {
+	case scheme == "semver":
+		return SemverScheme{}, nil
+	case strings.HasPrefix(scheme, "calver:"):
+		return NewCalVerScheme(strings.TrimPrefix(scheme, "calver:"))
+	}
+
+	return nil, fmt.Errorf("%s is not a valid versioning scheme, use semver or calver:format", scheme)
+}
+
+// TagFormat customizes the name of the tags of a scheme
+type TagFormat struct {
+	// Template wraps the version of tags, {version} is replaced with the version
+	Template string
+	// LeadingV forces the presence of a v before the version when defined,
+	// it's inferred from the latest tag otherwise
+	LeadingV *bool
+}
+
+// NewTagFormat creates a new instance of TagFormat,
+// the template must contain {version} once when it's defined
+func NewTagFormat(template string, leadingV *bool) (TagFormat, error) {
+	if template != "" && strings.Count(template, tagTemplateVersion) != 1 {
+		return TagFormat{}, fmt.Errorf("tag template %s must contain %s once", template, tagTemplateVersion)
+	}
+
+	return TagFormat{template, leadingV}, nil
+}
+
+// apply sets the format on a tag
+func (f TagFormat) apply(tag Tag) Tag {
+	tag.Template = f.Template
+
+	if f.LeadingV != nil {
+		tag.LeadingV = *f.LeadingV
+	}
+
+	return tag
+}
+
+// trim removes the text surrounding the version of a tag
+func (f TagFormat) trim(tag string) (string, bool) {
+	if f.Template == "" {
+		return tag, true
+	}
+
+	parts := strings.SplitN(f.Template, tagTemplateVersion, 2)
+
+	if len(tag) < len(parts[0])+len(parts[1]) || !strings.HasPrefix(tag, parts[0]) || !strings.HasSuffix(tag, parts[1]) {
+		return "", false
+	}
+
+	return tag[len(parts[0]) : len(tag)-len(parts[1])], true
+}
+
+// SemverScheme computes tags following semantic versioning,
+// ZeroMajorAsMinor bumps the minor part of 0.x versions for major
+// changes, 1.0.0 must then be released explicitly, InitialVersion
+// is the first tag of a repository when it's defined
+type SemverScheme struct {
+	TagFormat
+	ZeroMajorAsMinor bool
+	InitialVersion   *Tag
+}
+
+// First returns the initial version or bumps v0.0.0
+func (s SemverScheme) First(version Version) Tag {
+	if s.InitialVersion != nil {
+		return s.apply(*s.InitialVersion)
+	}
+
+	return s.Next(Tag{LeadingV: true}, version)
+}
+
+// Parse parses a semver tag
+func (s SemverScheme) Parse(tag string) (Tag, error) {
+	version, ok := s.trim(tag)
+	if !ok {
+		return Tag{}, fmt.Errorf("%s is not a valid semver tag", tag)
+	}
+
+	t, err := parseStringTag(version)
+	if err != nil {
+		return Tag{}, fmt.Errorf("%s is not a valid semver tag", tag)
+	}
+
+	t.Template = s.Template
+
+	return t, nil
+}
+
+// Next bumps the part of the tag matching the version
+func (s SemverScheme) Next(previousTag Tag, version Version) Tag {
+	if s.ZeroMajorAsMinor && previousTag.Major == 0 && version == MAJOR {
+		version = MINOR
+	}
+
+	return s.apply(getNextTag(previousTag, version))
+}
@@ -0,0 +1,116 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSchemeFromString(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		scheme string
+		test   func(Scheme, error)
+	}{
+		{
+			"Semver scheme",
+			"semver",
+			func(scheme Scheme, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, SemverScheme{}, scheme)
+			},
+		},
+		{
+			"Calver scheme",
+			"calver:YYYY.0M.MICRO",
+			func(scheme Scheme, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "YYYY.0M.MICRO", scheme.First(MINOR).Format)
+			},
+		},
+		{
+			"Calver scheme with an invalid format",
+			"calver:YYYY",
+			func(scheme Scheme, err error) {
+				assert.EqualError(t, err, "calver format YYYY must have 2 or 3 segments")
+			},
+		},
+		{
+			"Unknown scheme",
+			"romver",
+			func(scheme Scheme, err error) {
+				assert.EqualError(t, err, "romver is not a valid versioning scheme, use semver or calver:format")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(NewSchemeFromString(scenario.scheme))
+		})
+	}
+}
+
+func TestSemverScheme(t *testing.T) {
+	scheme := SemverScheme{}
+
+	tag, err := scheme.Parse("v1.2.3")
+	assert.NoError(t, err)
+	assert.Equal(t, "v1.3.0", scheme.Next(tag, MINOR).String())
+	assert.Equal(t, "v0.0.1", scheme.First(PATCH).String())
+	assert.Equal(t, "v1.0.0", scheme.Next(Tag{LeadingV: true, Minor: 4}, MAJOR).String())
+}
+
+func TestSemverSchemeWithZeroMajorAsMinor(t *testing.T) {
+	scheme := SemverScheme{ZeroMajorAsMinor: true}
+
+	assert.Equal(t, "v0.5.0", scheme.Next(Tag{LeadingV: true, Minor: 4, Patch: 2}, MAJOR).String())
+	assert.Equal(t, "v0.4.3", scheme.Next(Tag{LeadingV: true, Minor: 4, Patch: 2}, PATCH).String())
+	assert.Equal(t, "v0.1.0", scheme.First(MAJOR).String())
+	assert.Equal(t, "v2.0.0", scheme.Next(Tag{LeadingV: true, Major: 1, Minor: 4}, MAJOR).String())
+}
+
+func TestNewTagFormat(t *testing.T) {
+	leadingV := false
+
+	format, err := NewTagFormat("release-{version}-stable", &leadingV)
+	assert.NoError(t, err)
+	assert.Equal(t, TagFormat{"release-{version}-stable", &leadingV}, format)
+
+	_, err = NewTagFormat("release", nil)
+	assert.EqualError(t, err, "tag template release must contain {version} once")
+
+	_, err = NewTagFormat("{version}-{version}", nil)
+	assert.EqualError(t, err, "tag template {version}-{version} must contain {version} once")
+}
+
+func TestSemverSchemeWithTagFormat(t *testing.T) {
+	leadingV := false
+	scheme := SemverScheme{TagFormat: TagFormat{"release-{version}-stable", &leadingV}}
+
+	tag, err := scheme.Parse("release-v1.2.3-stable")
+	assert.NoError(t, err)
+	assert.Equal(t, Tag{LeadingV: true, Major: 1, Minor: 2, Patch: 3, Template: "release-{version}-stable"}, tag)
+	assert.Equal(t, "release-v1.2.3-stable", tag.String())
+	assert.Equal(t, "release-1.3.0-stable", scheme.Next(tag, MINOR).String())
+	assert.Equal(t, "release-0.0.1-stable", scheme.First(PATCH).String())
+
+	_, err = scheme.Parse("v1.2.3")
+	assert.EqualError(t, err, "v1.2.3 is not a valid semver tag")
+
+	_, err = scheme.Parse("release--stable")
+	assert.EqualError(t, err, "release--stable is not a valid semver tag")
+}
+
+func TestSemverSchemeWithInitialVersion(t *testing.T) {
+	scheme := SemverScheme{InitialVersion: &Tag{Minor: 1}}
+
+	assert.Equal(t, "0.1.0", scheme.First(MAJOR).String())
+	assert.Equal(t, "0.1.0", scheme.First(NORELEASE).String())
+
+	leadingV := true
+	scheme.TagFormat = TagFormat{LeadingV: &leadingV}
+
+	assert.Equal(t, "v0.1.0", scheme.First(PATCH).String())
+}
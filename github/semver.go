@@ -0,0 +1,74 @@
+package github
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Bump returns the next tag of a version, a pre-release whose lower
+// parts are already zero for the version is released instead,
+// 1.3.0-rc.1 bumped as minor gives 1.3.0
+func (t Tag) Bump(version Version) Tag {
+	if t.PreRelease != "" {
+		switch {
+		case version == PATCH,
+			version == MINOR && t.Patch == 0,
+			version == MAJOR && t.Minor == 0 && t.Patch == 0:
+			return getNextTag(t, NORELEASE)
+		}
+	}
+
+	return getNextTag(t, version)
+}
+
+// BumpPreRelease returns the next pre-release of a tag, the last numeric
+// identifier is incremented, a release gets the first pre-release of
+// the next patch, a new identifier restarts the pre-release at 0
+func (t Tag) BumpPreRelease(identifier string) Tag {
+	if t.PreRelease == "" {
+		next := getNextTag(t, PATCH)
+		next.PreRelease = strings.TrimPrefix(identifier+".0", ".")
+
+		return next
+	}
+
+	next := getNextTag(t, NORELEASE)
+
+	if identifier != "" && t.PreRelease != identifier && !strings.HasPrefix(t.PreRelease, identifier+".") {
+		next.PreRelease = identifier + ".0"
+		return next
+	}
+
+	ids := strings.Split(t.PreRelease, ".")
+
+	if n, err := strconv.Atoi(ids[len(ids)-1]); err == nil {
+		ids[len(ids)-1] = strconv.Itoa(n + 1)
+	} else {
+		ids = append(ids, "0")
+	}
+
+	next.PreRelease = strings.Join(ids, ".")
+
+	return next
+}
+
+// Compare returns -1, 0 or 1 when a tag has a lower, an equal
+// or a higher precedence than another one
+func (t Tag) Compare(other Tag) int {
+	switch {
+	case tagLess(t, other):
+		return -1
+	case tagLess(other, t):
+		return 1
+	}
+
+	return 0
+}
+
+// SortTags sorts tags from the lowest to the highest precedence
+func SortTags(tags []Tag) {
+	sort.SliceStable(tags, func(i int, j int) bool {
+		return tagLess(tags[i], tags[j])
+	})
+}
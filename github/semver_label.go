@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
 
 	"github.com/google/go-github/github"
 	"golang.org/x/oauth2"
@@ -15,6 +16,7 @@ const noreleaseStr = "norelease"
 const patchStr = "patch"
 const minorStr = "minor"
 const majorStr = "major"
+const statusContext = "versem"
 
 // Version represents a semver version
 // NORELEASE  => no version
@@ -37,6 +39,20 @@ const (
 	MAJOR
 )
 
+// PullRequest represents the pull request a commit belongs to
+type PullRequest struct {
+	Number int
+	Title  string
+	Body   string
+}
+
+type commitPullRequest struct {
+	Number int
+	Title  string
+	Body   string
+	Labels []github.Label
+}
+
 // SemverLabelService deals with pull requests
 // to manage semver labels
 type SemverLabelService struct {
@@ -88,50 +104,155 @@ func (s SemverLabelService) GetFromPullRequest(pullRequestNumber int) (Version,
 // if there is more than one this function returns an error,
 // if the commit doesn't belong to a pull request it returns a NORELEASE version
 func (s SemverLabelService) GetFromCommit(commitSha string) (Version, error) {
-	type PullRequest struct {
-		Labels []github.Label
+	results, err := s.getCommitPullRequests(commitSha)
+	if err != nil {
+		return UNVALIDVERSION, err
 	}
 
-	results := []PullRequest{}
+	if len(results) == 0 {
+		return NORELEASE, nil
+	} else if len(results) > 1 {
+		return UNVALIDVERSION, fmt.Errorf("several entries found associated with commit %s", commitSha)
+	}
 
-	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/pulls", s.owner, s.repository, commitSha), nil)
+	version, err := extractSemverLabels(results[0].Labels)
 	if err != nil {
-		return UNVALIDVERSION, fmt.Errorf("can't create github request : %s", err)
+		return UNVALIDVERSION, fmt.Errorf("can't parse version from commit %s : %s", commitSha, err)
 	}
 
-	req.Header.Add("Accept", "application/vnd.github.groot-preview+json")
+	return version, nil
+}
 
-	res, err := s.httpClient.Do(req)
+// GetPullRequestFromCommit find out the pull request a commit belongs to,
+// if multiple pull requests exist for this commit, it returns an error,
+// if the commit doesn't belong to a pull request it returns nil
+func (s SemverLabelService) GetPullRequestFromCommit(commitSha string) (*PullRequest, error) {
+	results, err := s.getCommitPullRequests(commitSha)
 	if err != nil {
-		return UNVALIDVERSION, fmt.Errorf("can't fetch github api to get label from commit %s : %s", commitSha, err)
+		return nil, err
 	}
 
-	if res.StatusCode != http.StatusOK {
-		return UNVALIDVERSION, fmt.Errorf("can't fetch github api to get label from commit %s : status code %d", commitSha, res.StatusCode)
+	if len(results) == 0 {
+		return nil, nil
+	} else if len(results) > 1 {
+		return nil, fmt.Errorf("several entries found associated with commit %s", commitSha)
 	}
 
-	body, err := io.ReadAll(res.Body)
+	return &PullRequest{
+		Number: results[0].Number,
+		Title:  results[0].Title,
+		Body:   results[0].Body,
+	}, nil
+}
+
+// GetComponentsFromPullRequest finds out the version of every component touched by a pull request,
+// a component takes the version of its name:version label or the semver label of the pull request
+// when it has none, if a touched component doesn't get exactly one version it returns an error
+func (s SemverLabelService) GetComponentsFromPullRequest(pullRequestNumber int, components []Component) ([]ComponentVersion, error) {
+	labels, _, err := s.client.Issues.ListLabelsByIssue(context.Background(), s.owner, s.repository, pullRequestNumber, nil)
 	if err != nil {
-		return UNVALIDVERSION, fmt.Errorf("can't parse github response : %s", err)
+		return nil, fmt.Errorf("can't fetch github api to get label for pull request #%d : %s", pullRequestNumber, err)
 	}
 
-	err = json.Unmarshal(body, &results)
+	ls := []github.Label{}
+
+	for _, l := range labels {
+		ls = append(ls, *l)
+	}
+
+	return s.getComponentVersions(pullRequestNumber, ls, components)
+}
+
+// GetComponentsFromCommit finds out the version of every component touched by the pull request
+// a commit belongs to like GetComponentsFromPullRequest, if the commit doesn't belong
+// to a pull request no component is returned
+func (s SemverLabelService) GetComponentsFromCommit(commitSha string, components []Component) ([]ComponentVersion, error) {
+	results, err := s.getCommitPullRequests(commitSha)
 	if err != nil {
-		return UNVALIDVERSION, fmt.Errorf("can't decode github json response : %s", err)
+		return nil, err
 	}
 
 	if len(results) == 0 {
-		return NORELEASE, nil
+		return []ComponentVersion{}, nil
 	} else if len(results) > 1 {
-		return UNVALIDVERSION, fmt.Errorf("several entries found associated with commit %s", commitSha)
+		return nil, fmt.Errorf("several entries found associated with commit %s", commitSha)
 	}
 
-	version, err := extractSemverLabels(results[0].Labels)
+	return s.getComponentVersions(results[0].Number, results[0].Labels, components)
+}
+
+func (s SemverLabelService) getComponentVersions(pullRequestNumber int, labels []github.Label, components []Component) ([]ComponentVersion, error) {
+	files, err := s.getPullRequestFiles(pullRequestNumber)
 	if err != nil {
-		return UNVALIDVERSION, fmt.Errorf("can't parse version from commit %s : %s", commitSha, err)
+		return nil, err
 	}
 
-	return version, nil
+	versions := []ComponentVersion{}
+
+	for _, component := range FilterComponents(components, files) {
+		version, err := extractComponentSemverLabels(labels, component.Name)
+		if err != nil {
+			return nil, fmt.Errorf("an error occurred when parsing version of component %s from pull request #%d : %s", component.Name, pullRequestNumber, err)
+		}
+
+		versions = append(versions, ComponentVersion{component, version})
+	}
+
+	return versions, nil
+}
+
+func (s SemverLabelService) getPullRequestFiles(pullRequestNumber int) ([]string, error) {
+	files := []string{}
+	options := &github.ListOptions{Page: 1, PerPage: 100}
+
+	for {
+		fs, res, err := s.client.PullRequests.ListFiles(context.Background(), s.owner, s.repository, pullRequestNumber, options)
+		if err != nil {
+			return nil, fmt.Errorf("can't fetch files of pull request #%d : %s", pullRequestNumber, err)
+		}
+
+		for _, f := range fs {
+			files = append(files, f.GetFilename())
+		}
+
+		if res.NextPage == 0 {
+			return files, nil
+		}
+
+		options.Page = res.NextPage
+	}
+}
+
+func (s SemverLabelService) getCommitPullRequests(commitSha string) ([]commitPullRequest, error) {
+	results := []commitPullRequest{}
+
+	req, err := http.NewRequest("GET", fmt.Sprintf("https://api.github.com/repos/%s/%s/commits/%s/pulls", s.owner, s.repository, commitSha), nil)
+	if err != nil {
+		return nil, fmt.Errorf("can't create github request : %s", err)
+	}
+
+	req.Header.Add("Accept", "application/vnd.github.groot-preview+json")
+
+	res, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("can't fetch github api to get label from commit %s : %s", commitSha, err)
+	}
+
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("can't fetch github api to get label from commit %s : status code %d", commitSha, res.StatusCode)
+	}
+
+	body, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, fmt.Errorf("can't parse github response : %s", err)
+	}
+
+	err = json.Unmarshal(body, &results)
+	if err != nil {
+		return nil, fmt.Errorf("can't decode github json response : %s", err)
+	}
+
+	return results, nil
 }
 
 // CreateList populates a repository with all labels needed
@@ -177,11 +298,52 @@ func (s SemverLabelService) CreateList() error {
 	return nil
 }
 
+// ReportStatus sets a commit status summarizing the semver label check of a pull request head commit
+func (s SemverLabelService) ReportStatus(commitSha string, version Version, checkErr error) error {
+	state := "success"
+	description := fmt.Sprintf("%s semver version found", strings.ToLower(version.String()))
+
+	if checkErr != nil {
+		state = "failure"
+		description = checkErr.Error()
+	}
+
+	statusName := statusContext
+
+	if _, _, err := s.client.Repositories.CreateStatus(context.Background(), s.owner, s.repository, commitSha, &github.RepoStatus{
+		State:       &state,
+		Description: &description,
+		Context:     &statusName,
+	}); err != nil {
+		return fmt.Errorf("can't create status on commit %s : %s", commitSha, err)
+	}
+
+	return nil
+}
+
 func extractSemverLabels(labels []github.Label) (Version, error) {
+	return getSingleVersion(listPrefixedSemverLabels(labels, ""))
+}
+
+// extractComponentSemverLabels finds out the version of a component from its name:version labels,
+// the semver label of the pull request applies when the component has none
+func extractComponentSemverLabels(labels []github.Label, component string) (Version, error) {
+	if versions := listPrefixedSemverLabels(labels, component+":"); len(versions) > 0 {
+		return getSingleVersion(versions)
+	}
+
+	return extractSemverLabels(labels)
+}
+
+func listPrefixedSemverLabels(labels []github.Label, prefix string) []Version {
 	versions := []Version{}
 
 	for _, label := range labels {
-		switch label.GetName() {
+		if !strings.HasPrefix(label.GetName(), prefix) {
+			continue
+		}
+
+		switch strings.TrimPrefix(label.GetName(), prefix) {
 		case noreleaseStr:
 			versions = append(versions, NORELEASE)
 		case patchStr:
@@ -193,6 +355,10 @@ func extractSemverLabels(labels []github.Label) (Version, error) {
 		}
 	}
 
+	return versions
+}
+
+func getSingleVersion(versions []Version) (Version, error) {
 	if len(versions) == 0 {
 		return UNVALIDVERSION, fmt.Errorf("no semver label found")
 	} else if len(versions) > 1 {
@@ -0,0 +1,84 @@
+package github
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTagBump(t *testing.T) {
+	scenarios := []struct {
+		tag      Tag
+		version  Version
+		expected string
+	}{
+		{Tag{LeadingV: true, Major: 1, Minor: 4, Patch: 2}, PATCH, "v1.4.3"},
+		{Tag{Major: 1, Minor: 4, Patch: 2}, MINOR, "1.5.0"},
+		{Tag{Major: 1, Minor: 4, Patch: 2, BuildMetadata: "g8a5ed82"}, MAJOR, "2.0.0"},
+		{Tag{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"}, PATCH, "1.2.3"},
+		{Tag{Major: 1, Minor: 3, PreRelease: "rc.1"}, MINOR, "1.3.0"},
+		{Tag{Major: 2, PreRelease: "beta"}, MAJOR, "2.0.0"},
+		{Tag{Major: 1, Minor: 2, Patch: 3, PreRelease: "rc.1"}, MINOR, "1.3.0"},
+		{Tag{Major: 1, Minor: 3, PreRelease: "rc.1"}, MAJOR, "2.0.0"},
+	}
+
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.expected, scenario.tag.Bump(scenario.version).String())
+	}
+}
+
+func TestTagBumpPreRelease(t *testing.T) {
+	scenarios := []struct {
+		tag        Tag
+		identifier string
+		expected   string
+	}{
+		{Tag{Major: 1, Minor: 2, Patch: 3}, "", "1.2.4-0"},
+		{Tag{Major: 1, Minor: 2, Patch: 3}, "rc", "1.2.4-rc.0"},
+		{Tag{Major: 1, Minor: 2, Patch: 4, PreRelease: "0"}, "", "1.2.4-1"},
+		{Tag{LeadingV: true, Major: 1, Minor: 2, Patch: 4, PreRelease: "rc.1"}, "", "v1.2.4-rc.2"},
+		{Tag{Major: 1, Minor: 2, Patch: 4, PreRelease: "rc.1"}, "rc", "1.2.4-rc.2"},
+		{Tag{Major: 1, Minor: 2, Patch: 4, PreRelease: "rc"}, "rc", "1.2.4-rc.0"},
+		{Tag{Major: 1, Minor: 2, Patch: 4, PreRelease: "beta.3"}, "rc", "1.2.4-rc.0"},
+		{Tag{Major: 1, Minor: 2, Patch: 4, PreRelease: "rc.1.a"}, "", "1.2.4-rc.1.a.0"},
+	}
+
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.expected, scenario.tag.BumpPreRelease(scenario.identifier).String())
+	}
+}
+
+func TestTagCompare(t *testing.T) {
+	scenarios := []struct {
+		a        Tag
+		b        Tag
+		expected int
+	}{
+		{Tag{Major: 1}, Tag{Major: 2}, -1},
+		{Tag{Major: 2}, Tag{Major: 1, Minor: 9}, 1},
+		{Tag{LeadingV: true, Major: 1}, Tag{Major: 1, BuildMetadata: "g8a5ed82"}, 0},
+		{Tag{Major: 1, PreRelease: "rc.1"}, Tag{Major: 1}, -1},
+	}
+
+	for _, scenario := range scenarios {
+		assert.Equal(t, scenario.expected, scenario.a.Compare(scenario.b))
+	}
+}
+
+func TestSortTags(t *testing.T) {
+	tags := []Tag{
+		{Major: 1, Minor: 10},
+		{Major: 1, Minor: 2},
+		{Major: 1, Minor: 10, PreRelease: "rc.1"},
+		{Major: 0, Minor: 9},
+	}
+
+	SortTags(tags)
+
+	assert.Equal(t, []Tag{
+		{Major: 0, Minor: 9},
+		{Major: 1, Minor: 2},
+		{Major: 1, Minor: 10, PreRelease: "rc.1"},
+		{Major: 1, Minor: 10},
+	}, tags)
+}
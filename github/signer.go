@@ -0,0 +1,51 @@
+package github
+
+import (
+	"bytes"
+	"fmt"
+	"os/exec"
+)
+
+// Signer signs the payload of git objects
+type Signer interface {
+	Sign(payload []byte) ([]byte, error)
+}
+
+// GPGSigner signs payloads with a gpg key
+// available in the local keyring
+type GPGSigner struct {
+	KeyID string
+}
+
+// Sign creates an armored detached signature of the payload
+func (g GPGSigner) Sign(payload []byte) ([]byte, error) {
+	return runSigner(exec.Command("gpg", "--batch", "--yes", "--armor", "--detach-sign", "--local-user", g.KeyID), payload)
+}
+
+// SSHSigner signs payloads with a local ssh private key
+type SSHSigner struct {
+	KeyFile string
+}
+
+// Sign creates an ssh signature of the payload in the git namespace
+func (s SSHSigner) Sign(payload []byte) ([]byte, error) {
+	return runSigner(exec.Command("ssh-keygen", "-Y", "sign", "-n", "git", "-f", s.KeyFile), payload)
+}
+
+func runSigner(cmd *exec.Cmd, payload []byte) ([]byte, error) {
+	stdout := bytes.Buffer{}
+	stderr := bytes.Buffer{}
+
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+
+	if err := cmd.Run(); err != nil {
+		if stderr.Len() > 0 {
+			return nil, fmt.Errorf("can't sign with %s : %s : %s", cmd.Args[0], err, bytes.TrimSpace(stderr.Bytes()))
+		}
+		return nil, fmt.Errorf("can't sign with %s : %s", cmd.Args[0], err)
+	}
+
+	return stdout.Bytes(), nil
+}
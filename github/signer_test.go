@@ -0,0 +1,52 @@
+package github
+
+import (
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunSigner(t *testing.T) {
+	scenarios := []struct {
+		name   string
+		getCmd func() *exec.Cmd
+		test   func([]byte, error)
+	}{
+		{
+			"Sign a payload",
+			func() *exec.Cmd {
+				return exec.Command("cat")
+			},
+			func(signature []byte, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, "payload", string(signature))
+			},
+		},
+		{
+			"Signing command fails",
+			func() *exec.Cmd {
+				return exec.Command("sh", "-c", "echo 'no secret key' >&2; exit 2")
+			},
+			func(signature []byte, err error) {
+				assert.EqualError(t, err, "can't sign with sh : exit status 2 : no secret key")
+			},
+		},
+		{
+			"Signing command fails without output",
+			func() *exec.Cmd {
+				return exec.Command("sh", "-c", "exit 1")
+			},
+			func(signature []byte, err error) {
+				assert.EqualError(t, err, "can't sign with sh : exit status 1")
+			},
+		},
+	}
+
+	for _, scenario := range scenarios {
+		scenario := scenario
+		t.Run(scenario.name, func(*testing.T) {
+			scenario.test(runSigner(scenario.getCmd(), []byte("payload")))
+		})
+	}
+}
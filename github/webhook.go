@@ -0,0 +1,157 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/google/go-github/github"
+)
+
+// WebhookHandler handles github pull request webhook events,
+// it checks semver labels when labels or commits change
+// and creates the release when a pull request is merged
+type WebhookHandler struct {
+	secret []byte
+	token  string
+	mutex  *sync.Mutex
+	locks  map[string]*sync.Mutex
+	scheme Scheme
+	branch string
+}
+
+// NewWebhookHandler creates a new instance of WebhookHandler
+func NewWebhookHandler(secret string, token string) WebhookHandler {
+	return WebhookHandler{
+		[]byte(secret),
+		token,
+		&sync.Mutex{},
+		map[string]*sync.Mutex{},
+		SemverScheme{},
+		"",
+	}
+}
+
+// WithScheme returns a copy of the handler computing tags with a scheme
+func (h WebhookHandler) WithScheme(scheme Scheme) WebhookHandler {
+	h.scheme = scheme
+	return h
+}
+
+// WithBranch returns a copy of the handler releasing only pull requests
+// merged into a branch, the default branch of the repository when empty
+func (h WebhookHandler) WithBranch(branch string) WebhookHandler {
+	h.branch = branch
+	return h
+}
+
+// ServeHTTP verifies the webhook signature and dispatches the event,
+// events of a same repository are processed one at a time
+func (h WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	payload, err := github.ValidatePayload(r, h.secret)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("can't validate payload : %s", err), http.StatusUnauthorized)
+		return
+	}
+
+	event, err := github.ParseWebHook(github.WebHookType(r), payload)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("can't parse payload : %s", err), http.StatusBadRequest)
+		return
+	}
+
+	e, ok := event.(*github.PullRequestEvent)
+	if !ok {
+		fmt.Fprintln(w, "event ignored")
+		return
+	}
+
+	owner := e.GetRepo().GetOwner().GetLogin()
+	repository := e.GetRepo().GetName()
+
+	lock := h.getLock(owner + "/" + repository)
+	lock.Lock()
+	defer lock.Unlock()
+
+	msg, err := h.handlePullRequest(owner, repository, e)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	fmt.Fprintln(w, msg)
+}
+
+func (h WebhookHandler) getLock(key string) *sync.Mutex {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if _, ok := h.locks[key]; !ok {
+		h.locks[key] = &sync.Mutex{}
+	}
+
+	return h.locks[key]
+}
+
+func (h WebhookHandler) handlePullRequest(owner string, repository string, e *github.PullRequestEvent) (string, error) {
+	labels := []github.Label{}
+
+	for _, l := range e.GetPullRequest().Labels {
+		labels = append(labels, *l)
+	}
+
+	version, versionErr := extractSemverLabels(labels)
+
+	switch e.GetAction() {
+	case "labeled", "unlabeled", "synchronize":
+		sha := e.GetPullRequest().GetHead().GetSHA()
+
+		if err := NewSemverLabelService(owner, repository, h.token).ReportStatus(sha, version, versionErr); err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("status reported on commit %s", sha), nil
+	case "closed":
+		if !e.GetPullRequest().GetMerged() {
+			return "pull request closed without merge, skip tag creation", nil
+		}
+
+		branch := h.branch
+		if branch == "" {
+			branch = e.GetRepo().GetDefaultBranch()
+		}
+
+		if base := e.GetPullRequest().GetBase().GetRef(); base != branch {
+			return fmt.Sprintf("pull request merged into %s instead of %s, skip tag creation", base, branch), nil
+		}
+
+		if versionErr != nil {
+			return "", fmt.Errorf("can't parse version from pull request #%d : %s", e.GetNumber(), versionErr)
+		}
+
+		if version == NORELEASE {
+			return "label norelease found, skip tag creation", nil
+		}
+
+		releaseService := NewReleaseService(owner, repository, h.token).WithScheme(h.scheme)
+		sha := e.GetPullRequest().GetMergeCommitSHA()
+
+		commitTag, err := releaseService.GetFromCommit(sha, nil)
+		if err != nil {
+			return "", err
+		}
+
+		if commitTag != nil {
+			return fmt.Sprintf("commit %s is already tagged with %s, skip tag creation", sha, commitTag), nil
+		}
+
+		tag, err := releaseService.CreateNext(version, sha, ReleaseOptions{})
+		if err != nil {
+			return "", err
+		}
+
+		return fmt.Sprintf("tag %s created", tag), nil
+	}
+
+	return fmt.Sprintf("action %s ignored", e.GetAction()), nil
+}